@@ -0,0 +1,71 @@
+package dynamic
+
+import (
+	"github.com/ready-steady/sort"
+	"github.com/turing-complete/time"
+)
+
+// PartitionSteps returns the number of time steps that Partition produces
+// without computing the power profile.
+func (self *Power) PartitionSteps(schedule *time.Schedule, ε float64) uint {
+	_, schedule = self.prune(schedule)
+	points := boundaries(schedule)
+	sort.Quick(points)
+	return count(points, ε, self.rtol)
+}
+
+// PartitionApprox is the same as Partition except that ε is chosen
+// automatically so that the number of time steps is close to target. The
+// number of time steps never exceeds target. Since it decreases in jumps as ε
+// grows, no ε might yield target exactly; in this case, the result has the
+// largest number of time steps below target that some ε yields, and ε is the
+// smallest such value up to the floating-point resolution.
+func (self *Power) PartitionApprox(schedule *time.Schedule, target uint) ([]float64, []float64) {
+	const (
+		iterations = 100
+	)
+
+	_, pruned := self.prune(schedule)
+	points := boundaries(pruned)
+	sort.Quick(points)
+
+	if count(points, 0, self.rtol) <= target {
+		return self.Partition(schedule, 0)
+	}
+
+	lower, upper := 0.0, points[len(points)-1]-points[0]
+	for i := 0; i < iterations; i++ {
+		middle := (lower + upper) / 2
+		if middle == lower || middle == upper {
+			break
+		}
+		if count(points, middle, self.rtol) <= target {
+			upper = middle
+		} else {
+			lower = middle
+		}
+	}
+
+	return self.Partition(schedule, upper)
+}
+
+// count returns the number of time steps produced by traverse for sorted
+// points.
+func count(points []float64, ε, rtol float64) uint {
+	np := len(points)
+	if np == 0 {
+		return 0
+	}
+
+	n := uint(0)
+	for i, x := 1, points[0]; i < np; i++ {
+		if δ := points[i] - x; apart(x, points[i], ε, rtol) {
+			x = points[i]
+			if δ > 0 {
+				n++
+			}
+		}
+	}
+
+	return n
+}
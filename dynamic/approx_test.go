@@ -0,0 +1,50 @@
+package dynamic
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestPartitionSteps(t *testing.T) {
+	power, schedule := prepare("002_040")
+
+	for _, ε := range []float64{-1, 0, 1e-14, 1e-3, 5e-3, 1e-2, 1} {
+		_, ΔT := power.Partition(schedule, ε)
+		assert.Equal(power.PartitionSteps(schedule, ε), uint(len(ΔT)), t)
+	}
+}
+
+func TestPartitionApprox(t *testing.T) {
+	power, schedule := prepare("002_040")
+
+	points := boundaries(schedule)
+	sort.Float64s(points)
+
+	attainable := map[uint]bool{}
+	for i := range points {
+		for k := i + 1; k < len(points); k++ {
+			δ := points[k] - points[i]
+			for _, ε := range []float64{math.Nextafter(δ, -1), δ, math.Nextafter(δ, 2*δ+1)} {
+				attainable[power.PartitionSteps(schedule, ε)] = true
+			}
+		}
+	}
+
+	for _, target := range []uint{5, 10, 20, 30, 39} {
+		expected := target
+		for !attainable[expected] {
+			expected--
+		}
+		P, ΔT := power.PartitionApprox(schedule, target)
+		ns := uint(len(ΔT))
+		assert.Equal(ns, expected, t)
+		assert.Equal(uint(len(P)), schedule.Cores*ns, t)
+	}
+
+	P, ΔT := power.PartitionApprox(schedule, 100)
+	assert.Equal(P, fixturePartition.P, t)
+	assert.Close(ΔT, fixturePartition.ΔT, 1e-15, t)
+}
@@ -0,0 +1,29 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+// Corrector is a correction of the power consumption of the cores that depends
+// on their temperature, such as temperature-dependent leakage, which allows for
+// coupling a power calculator with a thermal simulator.
+type Corrector interface {
+	// Correct adjusts the power consumption of the cores in place given their
+	// temperature.
+	Correct(temperature, power []float64)
+}
+
+// ProgressCorrected is the same as Progress except that the returned function
+// also takes the current temperature of the cores and passes it, together
+// with the power consumption computed as Progress does, to a corrector. In a
+// coupled power–thermal simulation, the temperature obtained at the previous
+// time step can thus be fed back into the power consumption of the next one.
+func (self *Power) ProgressCorrected(schedule *time.Schedule,
+	corrector Corrector) func(float64, []float64, []float64) {
+
+	compute := self.Progress(schedule)
+	return func(time float64, temperature, result []float64) {
+		compute(time, result)
+		corrector.Correct(temperature, result)
+	}
+}
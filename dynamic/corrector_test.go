@@ -0,0 +1,35 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+type linearLeakage struct {
+	reference, slope float64
+}
+
+func (self linearLeakage) Correct(temperature, power []float64) {
+	for j := range power {
+		power[j] += self.slope * (temperature[j] - self.reference)
+	}
+}
+
+func TestProgressCorrected(t *testing.T) {
+	power, schedule := prepare("002_040")
+	nc := schedule.Cores
+
+	compute := power.Progress(schedule)
+	correct := power.ProgressCorrected(schedule, linearLeakage{reference: 300, slope: 0.5})
+
+	expected, result := make([]float64, nc), make([]float64, nc)
+	temperature := []float64{300, 310}
+
+	for _, time := range []float64{0, 0.1, 0.2, schedule.Span} {
+		compute(time, expected)
+		expected[1] += 5
+		correct(time, temperature, result)
+		assert.Equal(result, expected, t)
+	}
+}
@@ -0,0 +1,25 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+// Distributor is a precompiled distribution of the power consumption of the
+// tasks of a particular schedule.
+type Distributor struct {
+	power []float64
+}
+
+// CompileDistributor prepares a distributor for a schedule. The power model is
+// evaluated once at this point; therefore, the distributor should be compiled
+// anew whenever the mapping of the schedule or the configuration of the power
+// calculator changes.
+func (self *Power) CompileDistributor(schedule *time.Schedule) *Distributor {
+	return &Distributor{power: self.Distribute(schedule)}
+}
+
+// Fill writes the power consumption of the tasks to out, which should be
+// preallocated for all the tasks. The function does not allocate.
+func (self *Distributor) Fill(out []float64) {
+	copy(out, self.power)
+}
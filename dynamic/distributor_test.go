@@ -0,0 +1,49 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestDistributor(t *testing.T) {
+	power, schedule := prepare("002_040")
+
+	expected := make([]float64, schedule.Tasks)
+	power.DistributeInto(schedule, expected)
+	assert.Equal(expected, []float64(power.Distribute(schedule)), t)
+
+	distributor := power.CompileDistributor(schedule)
+
+	out := make([]float64, schedule.Tasks)
+	distributor.Fill(out)
+	assert.Equal(out, expected, t)
+
+	allocations := testing.AllocsPerRun(10, func() {
+		distributor.Fill(out)
+	})
+	assert.Equal(allocations, 0.0, t)
+}
+
+func BenchmarkDistributeInto(b *testing.B) {
+	power, schedule := prepareRandom(32, 10000)
+	out := make([]float64, schedule.Tasks)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		power.DistributeInto(schedule, out)
+	}
+}
+
+func BenchmarkDistributorFill(b *testing.B) {
+	power, schedule := prepareRandom(32, 10000)
+	distributor := power.CompileDistributor(schedule)
+	out := make([]float64, schedule.Tasks)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		distributor.Fill(out)
+	}
+}
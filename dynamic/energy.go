@@ -0,0 +1,351 @@
+package dynamic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/turing-complete/time"
+)
+
+// Energy returns the total energy consumption of a schedule.
+func (self *Power) Energy(schedule *time.Schedule) float64 {
+	return sum(self.EnergyPerCore(schedule))
+}
+
+// EnergyPerCore returns the energy consumption of each core. The energy is
+// computed analytically from the start and finish times of the tasks.
+func (self *Power) EnergyPerCore(schedule *time.Schedule) []float64 {
+	dynamic, static := self.EnergyBreakdown(schedule)
+	for j := range dynamic {
+		dynamic[j] += static[j]
+	}
+	return dynamic
+}
+
+// EnergySubsetCores returns the total energy consumption of the given cores.
+func (self *Power) EnergySubsetCores(schedule *time.Schedule, cores []uint) float64 {
+	E := self.EnergyPerCore(schedule)
+	energy := 0.0
+	for _, j := range cores {
+		energy += E[j]
+	}
+	return energy
+}
+
+// EnergyFilter returns the total energy consumption of the cores for which the
+// filter returns true.
+func (self *Power) EnergyFilter(schedule *time.Schedule, filter func(uint) bool) float64 {
+	E := self.EnergyPerCore(schedule)
+	energy := 0.0
+	for j := range E {
+		if filter(uint(j)) {
+			energy += E[j]
+		}
+	}
+	return energy
+}
+
+// EnergyPerTask returns the energy consumption of each task, which is its power
+// consumption times its duration.
+func (self *Power) EnergyPerTask(schedule *time.Schedule) []float64 {
+	return self.TaskEnergyWindow(schedule, math.Inf(-1), math.Inf(1))
+}
+
+// EnergyBreakdown returns the dynamic and static energy consumption of each
+// core. The dynamic energy accrues only while tasks are running, and the static
+// one accrues over the whole span of the schedule. The idle energy, if an idle
+// power is set, is included in the static one.
+func (self *Power) EnergyBreakdown(schedule *time.Schedule) ([]float64, []float64) {
+	power, schedule := self.prune(schedule)
+	return self.breakdown(power, schedule)
+}
+
+func (self *Power) breakdown(power []float64, schedule *time.Schedule) ([]float64, []float64) {
+	nc := schedule.Cores
+
+	dynamic := window(power, schedule, math.Inf(-1), math.Inf(1))
+
+	static := self.idleEnergy(schedule, math.Inf(-1), math.Inf(1))
+	if self.static != nil {
+		for j := uint(0); j < nc; j++ {
+			static[j] += self.static[j] * schedule.Span
+		}
+	}
+
+	return dynamic, static
+}
+
+// EnergyWindow returns the energy consumption of each core within the time
+// interval [t0, t1).
+func (self *Power) EnergyWindow(schedule *time.Schedule, t0, t1 float64) []float64 {
+	nc := schedule.Cores
+
+	power, schedule := self.prune(schedule)
+
+	energy := window(power, schedule, t0, t1)
+	for j, e := range self.idleEnergy(schedule, t0, t1) {
+		energy[j] += e
+	}
+	if self.static != nil {
+		if Δ := math.Min(schedule.Span, t1) - math.Max(0, t0); Δ > 0 {
+			for j := uint(0); j < nc; j++ {
+				energy[j] += self.static[j] * Δ
+			}
+		}
+	}
+
+	return energy
+}
+
+// EnergyRanking returns the cores ordered by their energy consumption from the
+// highest to the lowest and the corresponding energy. Ties are broken by the
+// index of the core.
+func (self *Power) EnergyRanking(schedule *time.Schedule) ([]uint, []float64) {
+	energy := self.EnergyPerCore(schedule)
+
+	cores := make([]uint, len(energy))
+	for j := range cores {
+		cores[j] = uint(j)
+	}
+	sort.SliceStable(cores, func(a, b int) bool { return energy[cores[a]] > energy[cores[b]] })
+
+	values := make([]float64, len(cores))
+	for i, j := range cores {
+		values[i] = energy[j]
+	}
+
+	return cores, values
+}
+
+// TaskEnergyWindow returns the energy consumption of each task within the time
+// interval [t0, t1). The static energy consumption is not attributed to tasks.
+// The phases of the tasks, if any, are taken into account.
+func (self *Power) TaskEnergyWindow(schedule *time.Schedule, t0, t1 float64) []float64 {
+	power := self.Distribute(schedule)
+
+	E := make([]float64, schedule.Tasks)
+	phased, owners, factors := self.phase(schedule)
+	for k, i := range owners {
+		s := math.Max(phased.Start[k], t0)
+		f := math.Min(phased.Finish[k], t1)
+		if f > s {
+			E[i] += power[i] * factors[k] * (f - s)
+		}
+	}
+
+	return E
+}
+
+// EnergySplit returns the energy consumption of each core before and after a
+// time moment. The tasks running at the time moment are split accordingly; in
+// total, the two parts add up to the output of EnergyPerCore.
+func (self *Power) EnergySplit(schedule *time.Schedule, t float64) ([]float64, []float64) {
+	before := self.EnergyWindow(schedule, math.Inf(-1), t)
+	after := self.EnergyWindow(schedule, t, math.Inf(1))
+	return before, after
+}
+
+// EnergyPerType returns the dynamic energy consumption of the tasks of each
+// type.
+func (self *Power) EnergyPerType(schedule *time.Schedule) map[uint]float64 {
+	tasks := self.application.Tasks
+
+	energy := make(map[uint]float64)
+	for i, e := range self.EnergyPerTask(schedule) {
+		energy[tasks[i].Type] += e
+	}
+
+	return energy
+}
+
+// EnergyFractionByType returns the share of each task type in the total
+// dynamic energy consumption. The shares sum up to one unless no energy is
+// consumed, in which case all of them are zero.
+func (self *Power) EnergyFractionByType(schedule *time.Schedule) map[uint]float64 {
+	energy := self.EnergyPerType(schedule)
+
+	total := 0.0
+	for _, e := range energy {
+		total += e
+	}
+	for k := range energy {
+		if total == 0 {
+			energy[k] = 0
+		} else {
+			energy[k] /= total
+		}
+	}
+
+	return energy
+}
+
+// EnergyByTypeSorted is the same as EnergyPerType except that the result is
+// returned as a list of types in ascending order and a list of the
+// corresponding energies.
+func (self *Power) EnergyByTypeSorted(schedule *time.Schedule) ([]uint, []float64) {
+	energy := self.EnergyPerType(schedule)
+
+	types := make([]uint, 0, len(energy))
+	for k := range energy {
+		types = append(types, k)
+	}
+	sort.Slice(types, func(a, b int) bool { return types[a] < types[b] })
+
+	values := make([]float64, len(types))
+	for i, k := range types {
+		values[i] = energy[k]
+	}
+
+	return types, values
+}
+
+// CumulativeEnergyPerCore computes the energy consumption of each core
+// accumulated by the end of each sample with respect to a sampling interval Δt.
+// The energy of core j by the end of sample i is stored at i*nc+j. Unlike the
+// power profile of Sample, the energy is computed exactly; hence, the last
+// sample is equal to EnergyPerCore if the samples cover the whole schedule.
+func (self *Power) CumulativeEnergyPerCore(schedule *time.Schedule, Δt float64, ns uint) []float64 {
+	nc := schedule.Cores
+
+	E := make([]float64, nc*ns)
+
+	accumulate := func(j uint, p, start, finish float64) {
+		start, finish = math.Max(start, 0), math.Min(finish, float64(ns)*Δt)
+		for k := uint(start / Δt); k < ns && float64(k)*Δt < finish; k++ {
+			s := math.Max(start, float64(k)*Δt)
+			f := math.Min(finish, float64(k+1)*Δt)
+			if f > s {
+				E[k*nc+j] += p * (f - s)
+			}
+		}
+	}
+
+	power, schedule := self.prune(schedule)
+	for i := uint(0); i < schedule.Tasks; i++ {
+		accumulate(schedule.Mapping[i], power[i], schedule.Start[i], schedule.Finish[i])
+	}
+	if self.static != nil {
+		for j := uint(0); j < nc; j++ {
+			accumulate(j, self.static[j], 0, schedule.Span)
+		}
+	}
+	if self.idle != nil {
+		for j, pieces := range self.idling(schedule, gaps(schedule, 0)) {
+			for _, piece := range pieces {
+				accumulate(uint(j), piece.power, piece.start, piece.finish)
+			}
+		}
+	}
+
+	for k := uint(1); k < ns; k++ {
+		for j := uint(0); j < nc; j++ {
+			E[k*nc+j] += E[(k-1)*nc+j]
+		}
+	}
+
+	return E
+}
+
+// CheckConsistency verifies that the total energy consumption computed
+// analytically agrees with the integrals of the power profiles produced by
+// Partition and Sample. An error is returned if either of the integrals deviates
+// from the analytical value by more than tol. The parts of the span not covered
+// by the partition are accounted for at the static and idle power. The energy
+// computations evaluate an idle power that varies over time at the time
+// moments of Partition with ε equal to zero; hence, the partition agrees with
+// them when ε does not merge any moments, and the sample does up to the
+// sampling error.
+func (self *Power) CheckConsistency(schedule *time.Schedule, ε, Δt float64, ns uint,
+	tol float64) error {
+
+	nc := schedule.Cores
+
+	energy := self.Energy(schedule)
+
+	_, pruned := self.prune(schedule)
+	P, ΔT := self.Partition(schedule, ε)
+	partition := self.outside(pruned, sum(ΔT))
+	for i := range ΔT {
+		partition += sum(P[uint(i)*nc:uint(i+1)*nc]) * ΔT[i]
+	}
+	if math.Abs(partition-energy) > tol {
+		return fmt.Errorf("the energy of the partition is %g while it should be %g",
+			partition, energy)
+	}
+
+	P, _ = self.Sample(schedule, Δt, ns)
+	sample := sum(P) * Δt
+	if math.Abs(sample-energy) > tol {
+		return fmt.Errorf("the energy of the sample is %g while it should be %g",
+			sample, energy)
+	}
+
+	return nil
+}
+
+// TimeToEnergy returns the time moment at which the total energy consumption
+// accumulated since time zero reaches a budget. Since the power consumption is
+// constant between power switches, the time moment is computed exactly. The
+// second output is false if the budget is never reached.
+func (self *Power) TimeToEnergy(schedule *time.Schedule, budget float64) (float64, bool) {
+	if budget <= 0 {
+		return 0, true
+	}
+
+	power, pruned := self.prune(schedule)
+	levels, durations := self.levels(power, pruned, 0)
+
+	time := origin(pruned)
+	if time > 0 {
+		time = 0
+	}
+	energy := 0.0
+	for i := range durations {
+		p := levels[i]
+		if next := energy + p*durations[i]; p > 0 && next >= budget {
+			return time + (budget-energy)/p, true
+		}
+		energy += p * durations[i]
+		time += durations[i]
+	}
+
+	return 0, false
+}
+
+// SampleLoss returns the fraction of the total energy that falls outside the
+// window [0, ns×Δt) covered by Sample with the same arguments. The energy
+// includes the static and idle power consumption as it does for Energy, and
+// the output is zero if the total energy is zero.
+func (self *Power) SampleLoss(schedule *time.Schedule, Δt float64, ns uint) float64 {
+	total := self.Energy(schedule)
+	if total == 0 {
+		return 0
+	}
+
+	return (total - sum(self.EnergyWindow(schedule, 0, float64(ns)*Δt))) / total
+}
+
+func window(power []float64, schedule *time.Schedule, t0, t1 float64) []float64 {
+	nt := schedule.Tasks
+
+	E := make([]float64, schedule.Cores)
+
+	for i := uint(0); i < nt; i++ {
+		s := math.Max(schedule.Start[i], t0)
+		f := math.Min(schedule.Finish[i], t1)
+		if f > s {
+			E[schedule.Mapping[i]] += power[i] * (f - s)
+		}
+	}
+
+	return E
+}
+
+func sum(data []float64) float64 {
+	total := 0.0
+	for _, x := range data {
+		total += x
+	}
+	return total
+}
@@ -0,0 +1,312 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestEnergyBreakdown(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 4}, {3, 1}},
+		[]uint{0, 1, 1}, []uint{0, 0, 1},
+		[]float64{0, 1, 0}, []float64{1, 3, 2})
+
+	dynamic, static := power.EnergyBreakdown(schedule)
+	assert.Equal(dynamic, []float64{10, 2}, t)
+	assert.Equal(static, []float64{0, 0}, t)
+	assert.Equal(power.EnergyPerCore(schedule), []float64{10, 2}, t)
+	assert.Equal(power.Energy(schedule), 12.0, t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	dynamic, static = power.EnergyBreakdown(schedule)
+	assert.Equal(dynamic, []float64{10, 2}, t)
+	assert.Equal(static, []float64{1.5, 0.75}, t)
+
+	energy := power.EnergyPerCore(schedule)
+	for j := range energy {
+		assert.Equal(energy[j], dynamic[j]+static[j], t)
+	}
+	assert.Equal(power.Energy(schedule), 14.25, t)
+}
+
+func TestEnergyExact(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{3}}, []uint{0},
+		[]uint{0}, []float64{0.12}, []float64{0.57})
+
+	assert.Close(power.Energy(schedule), 3*0.45, 1e-15, t)
+
+	P, _ := power.Sample(schedule, 0.1, 6)
+	assert.Equal(P, []float64{0, 3, 3, 3, 3, 0}, t)
+	assert.Close(sum(P)*0.1, 3*0.4, 1e-15, t)
+}
+
+func TestEnergySubsetCores(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{1}, {2}, {3}, {4}},
+		[]uint{0, 0, 0, 0}, []uint{0, 1, 2, 3},
+		[]float64{0, 0, 0, 0}, []float64{1, 1, 1, 1})
+
+	assert.Equal(power.EnergySubsetCores(schedule, []uint{0, 1}), 3.0, t)
+	assert.Equal(power.EnergySubsetCores(schedule, []uint{2, 3}), 7.0, t)
+	assert.Equal(power.EnergySubsetCores(schedule, nil), 0.0, t)
+
+	assert.Equal(power.EnergyFilter(schedule, func(j uint) bool {
+		return j >= 2
+	}), 7.0, t)
+}
+
+func TestEnergyWindow(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{0, 1}, []float64{2, 4})
+
+	assert.Equal(power.EnergyWindow(schedule, 1, 3), []float64{2, 6}, t)
+	assert.Equal(power.EnergyWindow(schedule, -1, 5), power.EnergyPerCore(schedule), t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 0.5}))
+
+	assert.Equal(power.EnergyWindow(schedule, 1, 3), []float64{4, 7}, t)
+	assert.Equal(power.EnergyWindow(schedule, -1, 5), power.EnergyPerCore(schedule), t)
+}
+
+func TestEnergyFractionByType(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{1, 3}, {1, 3}},
+		[]uint{0, 1}, []uint{0, 1},
+		[]float64{0, 0}, []float64{1, 1})
+
+	assert.Equal(power.EnergyFractionByType(schedule), map[uint]float64{0: 0.25, 1: 0.75}, t)
+
+	power, schedule = prepareCustom([][]float64{{0}}, []uint{0},
+		[]uint{0}, []float64{0}, []float64{1})
+
+	assert.Equal(power.EnergyFractionByType(schedule), map[uint]float64{0: 0}, t)
+}
+
+func TestEnergyPerTask(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 4}, {3, 1}},
+		[]uint{0, 1, 1}, []uint{0, 0, 1},
+		[]float64{0, 1, 0}, []float64{1, 3, 2})
+
+	assert.Equal(power.EnergyPerTask(schedule), []float64{2, 8, 2}, t)
+	assert.Equal(sum(power.EnergyPerTask(schedule)), power.Energy(schedule), t)
+}
+
+func TestTaskEnergyWindow(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}}, []uint{0, 0, 0},
+		[]uint{0, 1, 0}, []float64{0, 1, 4}, []float64{2, 4, 5})
+
+	assert.Equal(power.TaskEnergyWindow(schedule, 1, 3), []float64{2, 6, 0}, t)
+
+	power, schedule = prepare("002_040")
+
+	test := func(t0, t1 float64) {
+		assert.Close(sum(power.TaskEnergyWindow(schedule, t0, t1)),
+			sum(power.EnergyWindow(schedule, t0, t1)), 1e-12, t)
+	}
+
+	test(0, schedule.Span)
+	test(0.1, 0.2)
+	test(-1, 0.05)
+}
+
+func TestEnergyPerType(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{1, 2, 3}, {4, 5, 6}},
+		[]uint{2, 0, 2, 1}, []uint{0, 0, 1, 1},
+		[]float64{0, 1, 0, 2}, []float64{1, 3, 2, 3})
+
+	energy := power.EnergyPerType(schedule)
+	assert.Equal(energy, map[uint]float64{0: 2, 1: 5, 2: 15}, t)
+
+	types, values := power.EnergyByTypeSorted(schedule)
+	assert.Equal(types, []uint{0, 1, 2}, t)
+	for i, k := range types {
+		assert.Equal(values[i], energy[k], t)
+	}
+
+	power, schedule = prepare("002_040")
+	types, values = power.EnergyByTypeSorted(schedule)
+	for i := 1; i < len(types); i++ {
+		assert.Equal(types[i] > types[i-1], true, t)
+	}
+	assert.Close(sum(values), power.Energy(schedule), 1e-12, t)
+}
+
+func TestCumulativeEnergyPerCore(t *testing.T) {
+	const (
+		Δt = 1e-3
+	)
+
+	power, schedule := prepare("002_040")
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+	nc, ns := schedule.Cores, uint(441)
+
+	E := power.CumulativeEnergyPerCore(schedule, Δt, ns)
+	for k := uint(1); k < ns; k++ {
+		for j := uint(0); j < nc; j++ {
+			assert.Equal(E[k*nc+j] >= E[(k-1)*nc+j], true, t)
+		}
+	}
+	assert.Close(E[(ns-1)*nc:], power.EnergyPerCore(schedule), 1e-12, t)
+
+	power, schedule = prepareCustom([][]float64{{2}}, []uint{0},
+		[]uint{0}, []float64{0.5}, []float64{2.5})
+
+	E = power.CumulativeEnergyPerCore(schedule, 1, 4)
+	assert.Equal(E, []float64{1, 3, 4, 4}, t)
+}
+
+func TestTimeToEnergy(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {4}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{1, 2}, []float64{3, 4})
+
+	test := func(budget, expected float64, found bool) {
+		time, ok := power.TimeToEnergy(schedule, budget)
+		assert.Equal(ok, found, t)
+		assert.Close(time, expected, 1e-14, t)
+	}
+
+	test(1, 1.5, true)
+	test(2, 2, true)
+	test(5, 2.5, true)
+	test(12, 4, true)
+	test(13, 0, false)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 1}))
+
+	test(1, 0.5, true)
+	test(2, 1, true)
+	test(6, 2, true)
+}
+
+func TestSampleLoss(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 4}}, []uint{0, 1},
+		[]uint{0, 0}, []float64{0, 1}, []float64{1, 2})
+
+	assert.Close(power.SampleLoss(schedule, 0.1, 10), 4.0/6.0, 1e-15, t)
+	assert.Close(power.SampleLoss(schedule, 0.1, 20), 0.0, 1e-15, t)
+	assert.Close(power.SampleLoss(schedule, 0.1, 15), 2.0/6.0, 1e-15, t)
+
+	power, schedule = prepareCustom([][]float64{{3}}, []uint{0},
+		[]uint{0}, []float64{0}, []float64{2})
+
+	assert.Close(power.SampleLoss(schedule, 0.1, 10), 0.5, 1e-15, t)
+
+	power, schedule = prepareCustom([][]float64{{2}}, []uint{0},
+		[]uint{0}, []float64{1}, []float64{2})
+	schedule.Span = 3
+
+	static := New(power.platform, power.application, WithStaticPower([]float64{1}))
+	assert.Close(static.SampleLoss(schedule, 1, 2), 1.0/5.0, 1e-15, t)
+	assert.Close(static.SampleLoss(schedule, 1, 3), 0.0, 1e-15, t)
+
+	idle := New(power.platform, power.application, WithConstantIdlePower([]float64{1}))
+	assert.Close(idle.SampleLoss(schedule, 1, 1), 3.0/4.0, 1e-15, t)
+	assert.Close(idle.SampleLoss(schedule, 1, 2), 1.0/4.0, 1e-15, t)
+}
+
+func TestCheckConsistency(t *testing.T) {
+	const (
+		ε  = 1e-14
+		Δt = 1e-5
+	)
+
+	power, schedule := prepare("002_040")
+	ns := uint(schedule.Span/Δt) + 1
+
+	assert.Success(power.CheckConsistency(schedule, ε, Δt, ns, 1e-3), t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+	assert.Success(power.CheckConsistency(schedule, ε, Δt, ns, 1e-3), t)
+
+	assert.Failure(power.CheckConsistency(schedule, 0.1, Δt, ns, 1e-3), t)
+	assert.Failure(power.CheckConsistency(schedule, ε, Δt, ns/2, 1e-3), t)
+
+	power = New(power.platform, power.application, WithConstantIdlePower([]float64{0.5, 0.25}))
+	assert.Success(power.CheckConsistency(schedule, ε, Δt, ns, 1e-3), t)
+}
+
+func TestEnergyIdle(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 0, 1},
+		[]float64{0, 3, 0}, []float64{1, 4, 4})
+	schedule.Span = 5
+
+	power = New(power.platform, power.application, WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	assert.Equal(power.EnergyPerCore(schedule), []float64{5.5, 12.25}, t)
+	assert.Equal(power.EnergyWindow(schedule, 2, 5), []float64{3, 6.25}, t)
+	assert.Equal(power.CumulativeEnergyPerCore(schedule, 1, 5)[8:], []float64{5.5, 12.25}, t)
+	assert.Success(power.CheckConsistency(schedule, ε, 1, 5, 1e-12), t)
+
+	energy := power.PartitionFuncEnergy(schedule, ε, func(float64, []float64) {})
+	assert.Equal(energy, 17.75, t)
+
+	budget, ok := power.TimeToEnergy(schedule, 17.5)
+	assert.Equal(ok, true, t)
+	assert.Close(budget, 5-0.25/0.75, 1e-12, t)
+}
+
+func TestEnergyIdleVarying(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 1, 1},
+		[]float64{0, 0, 2}, []float64{1, 2, 3})
+
+	power = New(power.platform, power.application, WithIdlePower(func(_ uint, time float64) float64 {
+		if time < 2 {
+			return 2
+		}
+		return 0.5
+	}))
+
+	assert.Equal(power.EnergyPerCore(schedule), []float64{2 + 2 + 0.5, 9}, t)
+	assert.Equal(power.EnergyWindow(schedule, 1.5, 3), []float64{1 + 0.5, 4.5}, t)
+	assert.Equal(power.CumulativeEnergyPerCore(schedule, 1, 3)[4:], []float64{4.5, 9}, t)
+	assert.Success(power.CheckConsistency(schedule, ε, 1, 3, 1e-12), t)
+
+	gating := &Gating{Threshold: 1.5, Sleep: []float64{0.25, 0.25}, Wakeup: []float64{0, 0}}
+	assert.Equal(power.EnergyGated(schedule, gating), []float64{2 + 2*0.25, 9}, t)
+}
+
+func TestEnergyRanking(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{1}, {3}, {1}, {2}},
+		[]uint{0, 0, 0, 0}, []uint{0, 1, 2, 3},
+		[]float64{0, 0, 0, 0}, []float64{2, 1, 2, 1})
+
+	cores, energy := power.EnergyRanking(schedule)
+	assert.Equal(cores, []uint{1, 0, 2, 3}, t)
+	assert.Equal(energy, []float64{3, 2, 2, 2}, t)
+}
+
+func TestEnergySplit(t *testing.T) {
+	power, schedule := prepare("002_040")
+
+	test := func() {
+		energy := power.EnergyPerCore(schedule)
+		for _, time := range []float64{-1, 0, 0.1, 0.2, schedule.Span, 2 * schedule.Span} {
+			before, after := power.EnergySplit(schedule, time)
+			for j := range energy {
+				assert.Close(before[j]+after[j], energy[j], 1e-12, t)
+			}
+		}
+	}
+
+	test()
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	test()
+
+	power, schedule = prepareCustom([][]float64{{2}}, []uint{0},
+		[]uint{0}, []float64{0}, []float64{4})
+
+	before, after := power.EnergySplit(schedule, 1)
+	assert.Equal(before, []float64{2}, t)
+	assert.Equal(after, []float64{6}, t)
+}
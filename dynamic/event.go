@@ -0,0 +1,137 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+// EachEvent invokes a function for each change in the power consumption of a
+// core in the order of time. The function receives the time moment, the core,
+// and the change in the power consumption of the core. The time moments of
+// power switches are merged in the same way as Partition does, and the changes
+// that occur on the same core at the same time moment are combined. The static
+// power consumption, if any, is switched on at time zero and off at the end of
+// the span of the schedule, as it is for Energy and Progress.
+func (self *Power) EachEvent(schedule *time.Schedule, ε float64,
+	fn func(float64, uint, float64)) {
+
+	power, schedule := self.prune(schedule)
+
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	ΔT, ssteps, fsteps := divide(schedule, ε, self.rtol)
+	ns := uint(len(ΔT))
+
+	events, offset := arrange(ssteps, fsteps, ns)
+
+	Δ := make([]float64, nc)
+
+	switches := []float64{}
+	if self.static != nil {
+		switches = []float64{0, schedule.Span}
+	}
+	toggle := func(s int) {
+		for j := uint(0); j < nc; j++ {
+			if s == 0 {
+				Δ[j] += self.static[j]
+			} else {
+				Δ[j] -= self.static[j]
+			}
+		}
+	}
+	flush := func(time float64, j uint) {
+		if Δ[j] != 0 {
+			fn(time, j, Δ[j])
+			Δ[j] = 0
+		}
+	}
+
+	s := 0
+	for k, l, time := uint(0), uint(0), origin(schedule); k <= ns; k++ {
+		for ; s < len(switches) && switches[s] < time; s++ {
+			toggle(s)
+			for j := uint(0); j < nc; j++ {
+				flush(switches[s], j)
+			}
+		}
+		combined := false
+		for ; s < len(switches) && switches[s] == time; s++ {
+			toggle(s)
+			combined = true
+		}
+		m := offset[k]
+		apply(Δ, power, schedule, events[l:m])
+		if combined {
+			for j := uint(0); j < nc; j++ {
+				flush(time, j)
+			}
+		} else {
+			for _, e := range events[l:m] {
+				flush(time, schedule.Mapping[e%nt])
+			}
+		}
+		if k < ns {
+			time += ΔT[k]
+		}
+		l = m
+	}
+	for ; s < len(switches); s++ {
+		toggle(s)
+		for j := uint(0); j < nc; j++ {
+			flush(switches[s], j)
+		}
+	}
+}
+
+// arrange groups the start and finish events of the tasks by the step at which
+// they occur. Event i < nt is the start of task i, and event nt+i is the finish
+// of task i. The events of step k are stored in events[offset[k-1]:offset[k]]
+// with offset[-1] being zero, and finish events precede start events.
+func arrange(ssteps, fsteps []uint, ns uint) ([]uint, []uint) {
+	nt := uint(len(ssteps))
+
+	offset := make([]uint, ns+2)
+	for i := uint(0); i < nt; i++ {
+		offset[ssteps[i]+1]++
+		offset[fsteps[i]+1]++
+	}
+	for k := uint(1); k <= ns+1; k++ {
+		offset[k] += offset[k-1]
+	}
+
+	events := make([]uint, 2*nt)
+	for i := uint(0); i < nt; i++ {
+		events[offset[fsteps[i]]] = nt + i
+		offset[fsteps[i]]++
+	}
+	for i := uint(0); i < nt; i++ {
+		events[offset[ssteps[i]]] = i
+		offset[ssteps[i]]++
+	}
+
+	return events, offset
+}
+
+// apply updates the power consumption of the cores given a range of events.
+func apply(row, power []float64, schedule *time.Schedule, events []uint) {
+	nt := schedule.Tasks
+	for _, e := range events {
+		if e < nt {
+			row[schedule.Mapping[e]] += power[e]
+		} else {
+			row[schedule.Mapping[e-nt]] -= power[e-nt]
+		}
+	}
+}
+
+// occupy updates the number of tasks running on each core given a range of
+// events.
+func occupy(busy []int, schedule *time.Schedule, events []uint) {
+	nt := schedule.Tasks
+	for _, e := range events {
+		if e < nt {
+			busy[schedule.Mapping[e]]++
+		} else {
+			busy[schedule.Mapping[e-nt]]--
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/time"
+)
+
+func TestEachEvent(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+	nc := schedule.Cores
+
+	P, times := []float64(nil), []float64(nil)
+	current := make([]float64, nc)
+	power.EachEvent(schedule, ε, func(time float64, j uint, Δ float64) {
+		if len(times) > 0 && times[len(times)-1] != time {
+			P = append(P, current...)
+		}
+		if len(times) == 0 || times[len(times)-1] != time {
+			times = append(times, time)
+		}
+		current[j] += Δ
+	})
+
+	assert.Close(P, fixturePartition.P, 1e-14, t)
+	assert.Close(current, []float64{0, 0}, 1e-14, t)
+
+	for i := range fixturePartition.ΔT {
+		assert.Close(times[i+1]-times[i], fixturePartition.ΔT[i], 1e-14, t)
+	}
+}
+
+func TestEachEventStatic(t *testing.T) {
+	type event struct {
+		time  float64
+		core  uint
+		delta float64
+	}
+
+	collect := func(power *Power, schedule *time.Schedule) []event {
+		events := []event{}
+		power.EachEvent(schedule, 0, func(time float64, j uint, Δ float64) {
+			events = append(events, event{time, j, Δ})
+		})
+		return events
+	}
+
+	power, schedule := prepareCustom([][]float64{{2}, {3}}, []uint{0},
+		[]uint{0}, []float64{0}, []float64{2})
+	schedule.Span = 3
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 0.5}))
+
+	assert.Equal(collect(power, schedule), []event{
+		{0, 0, 3}, {0, 1, 0.5}, {2, 0, -2}, {3, 0, -1}, {3, 1, -0.5},
+	}, t)
+
+	schedule.Start[0], schedule.Span = 1, 2
+
+	assert.Equal(collect(power, schedule), []event{
+		{0, 0, 1}, {0, 1, 0.5}, {1, 0, 2}, {2, 0, -3}, {2, 1, -0.5},
+	}, t)
+}
@@ -0,0 +1,296 @@
+package dynamic
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteMatrix writes a profile in the matrix format of gnuplot: one line per
+// time step with the power consumption of the cores separated by spaces.
+func (self *Profile) WriteMatrix(w io.Writer) error {
+	nc := self.Cores
+
+	buffer := bufio.NewWriter(w)
+	for i := uint(0); i < self.Steps; i++ {
+		for j := uint(0); j < nc; j++ {
+			if j > 0 {
+				buffer.WriteByte(' ')
+			}
+			buffer.WriteString(strconv.FormatFloat(self.P[i*nc+j], 'g', -1, 64))
+		}
+		buffer.WriteByte('\n')
+	}
+	return buffer.Flush()
+}
+
+// Columns returns the profile in a column-oriented layout. The first output is
+// the start time of each time step, and the second is the power consumption of
+// each core over the time steps. The columns of the cores share a single
+// contiguous array.
+func (self *Profile) Columns() ([]float64, [][]float64) {
+	nc, ns := self.Cores, self.Steps
+
+	time := make([]float64, ns)
+	for i, t := uint(0), self.Origin; i < ns; i++ {
+		time[i] = t
+		t += self.ΔT[i]
+	}
+
+	data := make([]float64, nc*ns)
+	cores := make([][]float64, nc)
+	for j := uint(0); j < nc; j++ {
+		cores[j] = data[j*ns : (j+1)*ns]
+		for i := uint(0); i < ns; i++ {
+			cores[j][i] = self.P[i*nc+j]
+		}
+	}
+
+	return time, cores
+}
+
+// WriteTo writes a profile in a binary format, which can be read back via
+// ReadProfile. The format consists of the number of cores and the number of
+// time steps as 64-bit unsigned integers followed by the origin, the durations
+// of the time steps, and the power values as 64-bit floating-point numbers, all
+// in little-endian byte order. The output is the number of bytes written to w,
+// including when an error occurs. An error is returned without writing
+// anything if the lengths of ΔT and P do not agree with Steps and Cores.
+func (self *Profile) WriteTo(w io.Writer) (int64, error) {
+	if uint(len(self.ΔT)) != self.Steps || uint(len(self.P)) != self.Cores*self.Steps {
+		return 0, errors.New("the durations and power values should agree with the numbers of steps and cores")
+	}
+
+	counter := &countingWriter{writer: w}
+	buffer := bufio.NewWriter(counter)
+
+	data := []interface{}{
+		uint64(self.Cores), uint64(self.Steps), self.Origin, self.ΔT, self.P,
+	}
+	for _, value := range data {
+		if err := binary.Write(buffer, binary.LittleEndian, value); err != nil {
+			return counter.count, err
+		}
+	}
+	err := buffer.Flush()
+
+	return counter.count, err
+}
+
+// ReadProfile reads a profile written by WriteTo. The data are read in chunks;
+// hence, the memory allocated is bounded by the size of the input rather than
+// by the sizes stated in the header.
+func ReadProfile(r io.Reader) (*Profile, error) {
+	const (
+		limit = 1 << 48
+	)
+
+	buffer := bufio.NewReader(r)
+
+	var header [2]uint64
+	if err := binary.Read(buffer, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	nc, ns := header[0], header[1]
+	if nc > limit || ns > limit || nc > 0 && ns > limit/nc {
+		return nil, errors.New("the profile is too large")
+	}
+
+	profile := &Profile{Cores: uint(nc), Steps: uint(ns)}
+	if err := binary.Read(buffer, binary.LittleEndian, &profile.Origin); err != nil {
+		return nil, err
+	}
+	var err error
+	if profile.ΔT, err = readFloats(buffer, ns); err != nil {
+		return nil, err
+	}
+	if profile.P, err = readFloats(buffer, nc*ns); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// WriteCSV writes a profile in the comma-separated-values format. The first
+// line is a header with the names of the columns: time, duration, and one
+// column per core named core0, core1, and so on. Each subsequent line
+// corresponds to a time step and contains its start time, its duration, and the
+// power consumption of the cores.
+func (self *Profile) WriteCSV(w io.Writer) error {
+	return self.writeDelimited(w, ',')
+}
+
+// WriteTSV is the same as WriteCSV except that the values are separated by
+// tabs.
+func (self *Profile) WriteTSV(w io.Writer) error {
+	return self.writeDelimited(w, '\t')
+}
+
+// ReadCSV reads a profile written by WriteCSV. The start time of the first
+// time step is taken as the origin, and the rest of the start times are
+// ignored.
+func ReadCSV(r io.Reader) (*Profile, error) {
+	return readDelimited(r, ',')
+}
+
+// ReadTSV reads a profile written by WriteTSV.
+func ReadTSV(r io.Reader) (*Profile, error) {
+	return readDelimited(r, '\t')
+}
+
+// WritePtrace writes a profile in the ptrace format of HotSpot: a header line
+// with the names of the functional units followed by one line per time step
+// with the power consumption of the units, all separated by tabs. The cores are
+// taken as the units; their names are given by names, and, if names is nil,
+// core0, core1, and so on are used. Since HotSpot assumes a constant sampling
+// interval, the time steps of the profile should have equal durations, which
+// is the case for sampled profiles.
+func (self *Profile) WritePtrace(w io.Writer, names []string) error {
+	nc := self.Cores
+
+	if names == nil {
+		names = make([]string, nc)
+		for j := range names {
+			names[j] = fmt.Sprintf("core%d", j)
+		}
+	}
+	if uint(len(names)) != nc {
+		return errors.New("the number of names should be equal to the number of cores")
+	}
+	for i := uint(1); i < self.Steps; i++ {
+		if self.ΔT[i] != self.ΔT[0] {
+			return errors.New("the time steps should have equal durations")
+		}
+	}
+
+	buffer := bufio.NewWriter(w)
+	for j, name := range names {
+		if j > 0 {
+			buffer.WriteByte('\t')
+		}
+		buffer.WriteString(name)
+	}
+	buffer.WriteByte('\n')
+	for i := uint(0); i < self.Steps; i++ {
+		for j := uint(0); j < nc; j++ {
+			if j > 0 {
+				buffer.WriteByte('\t')
+			}
+			buffer.WriteString(strconv.FormatFloat(self.P[i*nc+j], 'g', -1, 64))
+		}
+		buffer.WriteByte('\n')
+	}
+	return buffer.Flush()
+}
+
+func (self *Profile) writeDelimited(w io.Writer, comma rune) error {
+	nc := self.Cores
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	record := make([]string, 2+nc)
+	record[0], record[1] = "time", "duration"
+	for j := uint(0); j < nc; j++ {
+		record[2+j] = fmt.Sprintf("core%d", j)
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+
+	for i, time := uint(0), self.Origin; i < self.Steps; i++ {
+		record[0] = strconv.FormatFloat(time, 'g', -1, 64)
+		record[1] = strconv.FormatFloat(self.ΔT[i], 'g', -1, 64)
+		for j := uint(0); j < nc; j++ {
+			record[2+j] = strconv.FormatFloat(self.P[i*nc+j], 'g', -1, 64)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		time += self.ΔT[i]
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func readDelimited(r io.Reader, comma rune) (*Profile, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(header) < 2 || header[0] != "time" || header[1] != "duration" {
+		return nil, errors.New("the header should start with time and duration")
+	}
+
+	profile := &Profile{Cores: uint(len(header) - 2)}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		values := make([]float64, len(record))
+		for i := range record {
+			if values[i], err = strconv.ParseFloat(record[i], 64); err != nil {
+				return nil, fmt.Errorf("step %d has an invalid value %q", profile.Steps, record[i])
+			}
+		}
+		if profile.Steps == 0 {
+			profile.Origin = values[0]
+		}
+		profile.Steps++
+		profile.ΔT = append(profile.ΔT, values[1])
+		profile.P = append(profile.P, values[2:]...)
+	}
+
+	return profile, nil
+}
+
+// readFloats reads count little-endian 64-bit floating-point numbers in chunks
+// so that a truncated input is detected before the whole result is allocated.
+func readFloats(r io.Reader, count uint64) ([]float64, error) {
+	const (
+		chunk = 1 << 16
+	)
+
+	capacity := count
+	if capacity > chunk {
+		capacity = chunk
+	}
+	result, values := make([]float64, 0, capacity), make([]float64, capacity)
+	for remaining := count; remaining > 0; {
+		size := remaining
+		if size > chunk {
+			size = chunk
+		}
+		values := values[:size]
+		if err := binary.Read(r, binary.LittleEndian, values); err != nil {
+			return nil, err
+		}
+		result = append(result, values...)
+		remaining -= size
+	}
+
+	return result, nil
+}
+
+// countingWriter is a writer that counts the bytes written to another writer.
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (self *countingWriter) Write(data []byte) (int, error) {
+	n, err := self.writer.Write(data)
+	self.count += int64(n)
+	return n, err
+}
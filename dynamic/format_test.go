@@ -0,0 +1,160 @@
+package dynamic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestWriteMatrix(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+	profile := power.PartitionProfile(schedule, ε)
+
+	buffer := new(bytes.Buffer)
+	assert.Success(profile.WriteMatrix(buffer), t)
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	assert.Equal(uint(len(lines)), profile.Steps, t)
+	for _, line := range lines {
+		assert.Equal(uint(len(strings.Fields(line))), profile.Cores, t)
+	}
+	assert.Equal(lines[0], "12.48 0", t)
+	assert.Equal(lines[4], "13.07 1.47", t)
+}
+
+func TestColumns(t *testing.T) {
+	profile := &Profile{
+		Cores:  2,
+		Steps:  3,
+		P:      []float64{1, 2, 3, 4, 5, 6},
+		ΔT:     []float64{1, 2, 0.5},
+		Origin: 1,
+	}
+
+	time, cores := profile.Columns()
+	assert.Equal(time, []float64{1, 2, 4}, t)
+	assert.Equal(cores, [][]float64{{1, 3, 5}, {2, 4, 6}}, t)
+}
+
+func TestWriteTo(t *testing.T) {
+	profile := &Profile{
+		Cores:  2,
+		Steps:  3,
+		P:      []float64{1, 2, 3, 4, 5, 6},
+		ΔT:     []float64{1, 2, 0.5},
+		Origin: 1,
+	}
+
+	buffer := new(bytes.Buffer)
+	n, err := profile.WriteTo(buffer)
+	assert.Success(err, t)
+	assert.Equal(n, int64(buffer.Len()), t)
+
+	result, err := ReadProfile(buffer)
+	assert.Success(err, t)
+	assert.Equal(result, profile, t)
+
+	_, err = ReadProfile(bytes.NewReader([]byte{1, 2, 3}))
+	assert.Failure(err, t)
+
+	header := func(nc, ns uint64) *bytes.Buffer {
+		buffer := new(bytes.Buffer)
+		binary.Write(buffer, binary.LittleEndian, []uint64{nc, ns})
+		return buffer
+	}
+	_, err = ReadProfile(header(0, 1<<62))
+	assert.Failure(err, t)
+	_, err = ReadProfile(header(1<<20, 1<<20))
+	assert.Failure(err, t)
+	_, err = ReadProfile(header(1<<10, 1<<30))
+	assert.Failure(err, t)
+
+	invalid := *profile
+	invalid.P = invalid.P[:5]
+	buffer.Reset()
+	n, err = invalid.WriteTo(buffer)
+	assert.Failure(err, t)
+	assert.Equal(n, int64(0), t)
+	assert.Equal(buffer.Len(), 0, t)
+
+	writer := &limitedWriter{limit: 20}
+	n, err = profile.WriteTo(writer)
+	assert.Failure(err, t)
+	assert.Equal(n, int64(20), t)
+}
+
+type limitedWriter struct {
+	limit int
+}
+
+func (self *limitedWriter) Write(data []byte) (int, error) {
+	if len(data) > self.limit {
+		n := self.limit
+		self.limit = 0
+		return n, io.ErrShortWrite
+	}
+	self.limit -= len(data)
+	return len(data), nil
+}
+
+func TestWriteCSV(t *testing.T) {
+	profile := &Profile{
+		Cores:  2,
+		Steps:  3,
+		P:      []float64{1, 2, 3, 4, 5, 6},
+		ΔT:     []float64{1, 2, 0.5},
+		Origin: 1,
+	}
+
+	buffer := new(bytes.Buffer)
+	assert.Success(profile.WriteCSV(buffer), t)
+	assert.Equal(buffer.String(), "time,duration,core0,core1\n"+
+		"1,1,1,2\n2,2,3,4\n4,0.5,5,6\n", t)
+
+	result, err := ReadCSV(buffer)
+	assert.Success(err, t)
+	assert.Equal(result, profile, t)
+
+	buffer.Reset()
+	assert.Success(profile.WriteTSV(buffer), t)
+	assert.Equal(strings.SplitN(buffer.String(), "\n", 2)[0], "time\tduration\tcore0\tcore1", t)
+
+	result, err = ReadTSV(buffer)
+	assert.Success(err, t)
+	assert.Equal(result, profile, t)
+
+	_, err = ReadCSV(strings.NewReader("start,duration\n"))
+	assert.Failure(err, t)
+	_, err = ReadCSV(strings.NewReader("time,duration,core0\n0,1,2,3\n"))
+	assert.Failure(err, t)
+	_, err = ReadCSV(strings.NewReader("time,duration,core0\n0,1,x\n"))
+	assert.Failure(err, t)
+}
+
+func TestWritePtrace(t *testing.T) {
+	power, schedule := prepare("002_040")
+	profile := power.SampleProfile(schedule, 1e-3, 3)
+
+	buffer := new(bytes.Buffer)
+	assert.Success(profile.WritePtrace(buffer, []string{"A", "B"}), t)
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	assert.Equal(uint(len(lines)), profile.Steps+1, t)
+	assert.Equal(lines[0], "A\tB", t)
+	assert.Equal(lines[1], "12.48\t0", t)
+
+	buffer.Reset()
+	assert.Success(profile.WritePtrace(buffer, nil), t)
+	assert.Equal(strings.SplitN(buffer.String(), "\n", 2)[0], "core0\tcore1", t)
+
+	assert.Failure(profile.WritePtrace(buffer, []string{"A"}), t)
+	assert.Failure(power.PartitionProfile(schedule, 1e-14).WritePtrace(buffer, nil), t)
+}
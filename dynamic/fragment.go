@@ -0,0 +1,114 @@
+package dynamic
+
+import (
+	"math"
+
+	"github.com/turing-complete/time"
+)
+
+// Fragment is a part of the execution of a preempted task.
+type Fragment struct {
+	Core   uint
+	Start  float64
+	Finish float64
+}
+
+// PartitionFragmented is the same as Partition except that tasks can be
+// preempted and resumed, possibly on a different core. The fragments of task i
+// are given by fragments[i] in chronological order, and the power consumption
+// of the task on the core of each fragment is drawn during that fragment only;
+// the fragments can be checked using ValidateFragments. If fragments or
+// fragments[i] is nil, the task is assumed to run contiguously as given by the
+// schedule. The phases of a preempted task, if any, are laid out over the
+// concatenation of its fragments.
+func (self *Power) PartitionFragmented(schedule *time.Schedule, fragments [][]Fragment,
+	ε float64) ([]float64, []float64) {
+
+	power, schedule := self.exclude(self.fragment(schedule, fragments))
+	return self.partitionInto(power, schedule, ε, nil, nil)
+}
+
+// SampleFragmented is the same as Sample except that tasks can be preempted as
+// described in PartitionFragmented.
+func (self *Power) SampleFragmented(schedule *time.Schedule, fragments [][]Fragment,
+	Δt float64, ns uint) ([]float64, uint) {
+
+	P := make([]float64, schedule.Cores*ns)
+	power, schedule := self.exclude(self.fragment(schedule, fragments))
+	return P, self.sampleInto(schedule, power, Δt, ns, P)
+}
+
+// fragment replaces each preempted task with one task per fragment and phase
+// and distributes the power consumption of the resulting tasks.
+func (self *Power) fragment(schedule *time.Schedule,
+	fragments [][]Fragment) ([]float64, *time.Schedule) {
+
+	if fragments == nil {
+		return self.split(self.Distribute(schedule), schedule)
+	}
+
+	nt := schedule.Tasks
+
+	result := *schedule
+	result.Tasks = 0
+	result.Mapping = make([]uint, 0, nt)
+	result.Start = make([]float64, 0, nt)
+	result.Finish = make([]float64, 0, nt)
+
+	power := make([]float64, 0, nt)
+	add := func(i, j uint, start, finish, factor float64) {
+		result.Tasks++
+		result.Mapping = append(result.Mapping, j)
+		result.Start = append(result.Start, start)
+		result.Finish = append(result.Finish, finish)
+		power = append(power, factor*self.lookup(j, i))
+	}
+
+	for i := uint(0); i < nt; i++ {
+		var parts []Fragment
+		if i < uint(len(fragments)) {
+			parts = fragments[i]
+		}
+		if parts == nil {
+			parts = []Fragment{{
+				Core:   schedule.Mapping[i],
+				Start:  schedule.Start[i],
+				Finish: schedule.Finish[i],
+			}}
+		}
+		var phases []Phase
+		if i < uint(len(self.phases)) {
+			phases = self.phases[i]
+		}
+		if len(phases) == 0 {
+			for _, part := range parts {
+				add(i, part.Core, part.Start, part.Finish, 1)
+			}
+			continue
+		}
+		duration := 0.0
+		for _, part := range parts {
+			duration += part.Finish - part.Start
+		}
+		elapsed := 0.0
+		for _, part := range parts {
+			lower, upper := elapsed, elapsed+part.Finish-part.Start
+			begin, fraction := 0.0, 0.0
+			for k, phase := range phases {
+				fraction += phase.Fraction
+				end := fraction * duration
+				if k == len(phases)-1 {
+					end = duration
+				}
+				s, f := math.Max(begin, lower), math.Min(end, upper)
+				if f > s {
+					add(i, part.Core, part.Start+s-lower, part.Start+f-lower, phase.Power)
+				}
+				begin = end
+			}
+			elapsed = upper
+		}
+	}
+
+	return power, &result
+}
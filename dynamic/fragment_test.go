@@ -0,0 +1,63 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestPartitionFragmented(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2, 6}, {4, 8}},
+		[]uint{0, 1}, []uint{0, 1},
+		[]float64{0, 1}, []float64{3, 3})
+
+	P, ΔT := power.PartitionFragmented(schedule, nil, ε)
+	assert.Equal(P, []float64{2, 0, 2, 8}, t)
+	assert.Equal(ΔT, []float64{1, 2}, t)
+
+	fragments := [][]Fragment{{{Core: 0, Start: 0, Finish: 1}, {Core: 1, Start: 2, Finish: 3}}, nil}
+
+	P, ΔT = power.PartitionFragmented(schedule, fragments, ε)
+	assert.Equal(P, []float64{2, 0, 0, 8, 0, 12}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	P, count := power.SampleFragmented(schedule, fragments, 0.5, 6)
+	assert.Equal(P, []float64{2, 0, 2, 0, 0, 8, 0, 8, 0, 12, 0, 12}, t)
+	assert.Equal(count, uint(6), t)
+}
+
+func TestPartitionFragmentedIdle(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2, 6}, {4, 8}},
+		[]uint{0, 1}, []uint{0, 1},
+		[]float64{0, 1}, []float64{3, 3})
+	power = New(power.platform, power.application,
+		WithIdlePower(func(uint, float64) float64 { return 0.5 }))
+
+	fragments := [][]Fragment{{{Core: 0, Start: 0, Finish: 1}, {Core: 1, Start: 2, Finish: 3}}, nil}
+
+	P, ΔT := power.PartitionFragmented(schedule, fragments, ε)
+	assert.Equal(P, []float64{2, 0.5, 0.5, 8, 0.5, 12}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	P, count := power.SampleFragmented(schedule, fragments, 1, 3)
+	assert.Equal(P, []float64{2, 0.5, 0.5, 8, 0.5, 12}, t)
+	assert.Equal(count, uint(3), t)
+
+	P, ΔT = power.PartitionFragmented(schedule, fragments[:1], ε)
+	assert.Equal(P, []float64{2, 0.5, 0.5, 8, 0.5, 12}, t)
+
+	phases := [][]Phase{{{Fraction: 0.75, Power: 1}, {Fraction: 0.25, Power: 3}}, nil}
+	power = New(power.platform, power.application, WithPhases(phases))
+
+	P, ΔT = power.PartitionFragmented(schedule, fragments, ε)
+	assert.Equal(P, []float64{2, 0, 0, 8, 0, 12, 0, 20}, t)
+	assert.Equal(ΔT, []float64{1, 1, 0.5, 0.5}, t)
+}
@@ -0,0 +1,116 @@
+package dynamic
+
+import (
+	"sort"
+
+	"github.com/turing-complete/time"
+)
+
+// Gating is a power-gating policy. A core is put into a sleep state whenever it
+// stays idle for at least Threshold, in which case it draws Sleep[j] instead of
+// its static and idle power consumption, and each transition from the sleep
+// state back to running tasks costs Wakeup[j] of energy. A policy can be checked
+// using ValidateGating.
+type Gating struct {
+	Threshold float64
+	Sleep     []float64
+	Wakeup    []float64
+}
+
+// gap is an idle interval of a core.
+type gap struct {
+	start  float64
+	finish float64
+	wakeup bool
+}
+
+// SampleGated is the same as Sample except that the cores are gated according
+// to a policy. The samples of gated intervals carry the sleep power, and the
+// energy of each wake-up is spread over the sample at which the core wakes up.
+// The idle intervals are the ones left after the tasks excluded by the power
+// floor, if set, are removed, as they are for EnergyGated.
+func (self *Power) SampleGated(schedule *time.Schedule, gating *Gating, Δt float64,
+	ns uint) ([]float64, uint) {
+
+	nc := schedule.Cores
+
+	P, count := self.Sample(schedule, Δt, ns)
+
+	_, pruned := self.prune(schedule)
+	for j, intervals := range gaps(pruned, gating.Threshold) {
+		for _, gap := range intervals {
+			s, f := uint(gap.start/Δt+0.5), uint(gap.finish/Δt+0.5)
+			if f > count {
+				f = count
+			}
+			for k := s; k < f; k++ {
+				P[k*nc+uint(j)] = gating.Sleep[j]
+			}
+			if gap.wakeup && f < count {
+				P[f*nc+uint(j)] += gating.Wakeup[j] / Δt
+			}
+		}
+	}
+
+	return P, count
+}
+
+// EnergyGated is the same as EnergyPerCore except that the cores are gated
+// according to a policy. Over the gated intervals, the sleep power consumption
+// replaces the static and idle ones, and the energy of the wake-ups is added.
+func (self *Power) EnergyGated(schedule *time.Schedule, gating *Gating) []float64 {
+	energy := self.EnergyPerCore(schedule)
+
+	_, pruned := self.prune(schedule)
+	intervals := gaps(pruned, gating.Threshold)
+	for j := range intervals {
+		static := 0.0
+		if self.static != nil {
+			static = self.static[j]
+		}
+		for _, gap := range intervals[j] {
+			energy[j] += (gating.Sleep[j] - static) * (gap.finish - gap.start)
+			if gap.wakeup {
+				energy[j] += gating.Wakeup[j]
+			}
+		}
+	}
+	if self.idle != nil {
+		for j, pieces := range self.idling(pruned, intervals) {
+			for _, piece := range pieces {
+				energy[j] -= piece.power * (piece.finish - piece.start)
+			}
+		}
+	}
+
+	return energy
+}
+
+// gaps returns the idle intervals of each core within the span of a schedule
+// that last at least threshold.
+func gaps(schedule *time.Schedule, threshold float64) [][]gap {
+	result := make([][]gap, schedule.Cores)
+
+	for j, tasks := range group(schedule) {
+		sort.Slice(tasks, func(a, b int) bool {
+			return schedule.Start[tasks[a]] < schedule.Start[tasks[b]]
+		})
+
+		add := func(start, finish float64, wakeup bool) {
+			if finish > start && finish-start >= threshold {
+				result[j] = append(result[j], gap{start: start, finish: finish, wakeup: wakeup})
+			}
+		}
+
+		time := 0.0
+		for _, i := range tasks {
+			add(time, schedule.Start[i], true)
+			if schedule.Finish[i] > time {
+				time = schedule.Finish[i]
+			}
+		}
+		add(time, schedule.Span, false)
+	}
+
+	return result
+}
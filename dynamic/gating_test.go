@@ -0,0 +1,65 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestEnergyGated(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 0, 1},
+		[]float64{0, 3, 0}, []float64{1, 4, 1})
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 1}))
+
+	gating := &Gating{Threshold: 1.5, Sleep: []float64{0.25, 0.5}, Wakeup: []float64{2, 3}}
+
+	assert.Equal(power.EnergyPerCore(schedule), []float64{8, 7}, t)
+	assert.Equal(power.EnergyGated(schedule, gating), []float64{8 - 2*0.75 + 2, 7 - 3*0.5}, t)
+
+	gating.Threshold = 5
+	assert.Equal(power.EnergyGated(schedule, gating), power.EnergyPerCore(schedule), t)
+}
+
+func TestSampleGated(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 0, 1},
+		[]float64{0, 3, 0}, []float64{1, 4, 1})
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 1}))
+
+	gating := &Gating{Threshold: 1.5, Sleep: []float64{0.25, 0.5}, Wakeup: []float64{2, 3}}
+
+	P, count := power.SampleGated(schedule, gating, 1, 4)
+	assert.Equal(count, uint(4), t)
+	assert.Equal(P, []float64{3, 4, 0.25, 0.5, 0.25, 0.5, 5, 0.5}, t)
+}
+
+func TestGatedIdle(t *testing.T) {
+	const (
+		Δt = 0.5
+		ns = 8
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 0, 1},
+		[]float64{0, 3, 0}, []float64{1, 4, 1})
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 1}),
+		WithConstantIdlePower([]float64{0.5, 1}))
+
+	gating := &Gating{Threshold: 1.5, Sleep: []float64{0.25, 0.5}, Wakeup: []float64{2, 3}}
+
+	assert.Equal(power.EnergyPerCore(schedule), []float64{9, 10}, t)
+	assert.Equal(power.EnergyGated(schedule, gating), []float64{9 - 2*1.25 + 2, 10 - 3*1.5}, t)
+
+	P, _ := power.SampleGated(schedule, gating, Δt, ns)
+	energy := make([]float64, 2)
+	for k := 0; k < ns; k++ {
+		for j := range energy {
+			energy[j] += P[k*2+j] * Δt
+		}
+	}
+	assert.Equal(energy, power.EnergyGated(schedule, gating), t)
+}
@@ -0,0 +1,70 @@
+package dynamic
+
+import (
+	"fmt"
+	"os"
+)
+
+// GoldenProfile compares a profile with the one stored in a golden file in the
+// format of WriteTo. If update is true, the golden file is overwritten with the
+// profile instead. If the profiles differ, the error describes the first
+// difference.
+func GoldenProfile(profile *Profile, path string, update bool) error {
+	if update {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if _, err := profile.WriteTo(file); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	golden, err := ReadProfile(file)
+	if err != nil {
+		return err
+	}
+
+	return compare(profile, golden)
+}
+
+// compare returns an error describing the first difference between a profile
+// and the expected one.
+func compare(profile, expected *Profile) error {
+	if profile.Cores != expected.Cores {
+		return fmt.Errorf("the profile has %d cores while %d are expected",
+			profile.Cores, expected.Cores)
+	}
+	if profile.Steps != expected.Steps {
+		return fmt.Errorf("the profile has %d time steps while %d are expected",
+			profile.Steps, expected.Steps)
+	}
+	if profile.Origin != expected.Origin {
+		return fmt.Errorf("the origin is %g while %g is expected",
+			profile.Origin, expected.Origin)
+	}
+
+	nc := profile.Cores
+	for i := uint(0); i < profile.Steps; i++ {
+		if profile.ΔT[i] != expected.ΔT[i] {
+			return fmt.Errorf("the duration of time step %d is %g while %g is expected",
+				i, profile.ΔT[i], expected.ΔT[i])
+		}
+		for j := uint(0); j < nc; j++ {
+			if p, q := profile.P[i*nc+j], expected.P[i*nc+j]; p != q {
+				return fmt.Errorf("the power of core %d at time step %d is %g while %g is expected",
+					j, i, p, q)
+			}
+		}
+	}
+
+	return nil
+}
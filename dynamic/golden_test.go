@@ -0,0 +1,30 @@
+package dynamic
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestGoldenProfile(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+	profile := power.PartitionProfile(schedule, ε)
+
+	path := filepath.Join(t.TempDir(), "profile.bin")
+
+	assert.Failure(GoldenProfile(profile, path, false), t)
+	assert.Success(GoldenProfile(profile, path, true), t)
+	assert.Success(GoldenProfile(profile, path, false), t)
+
+	other := power.PartitionProfile(schedule, ε)
+	other.P[3*other.Cores+1] += 1
+
+	err := GoldenProfile(other, path, false)
+	assert.Failure(err, t)
+	assert.Equal(err.Error(), "the power of core 1 at time step 3 is 12.21 while 11.21 is expected", t)
+}
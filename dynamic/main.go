@@ -1,7 +1,15 @@
 // Package dynamic provides algorithms for calculating the dynamic power.
+//
+// The power consumption of a task is allowed to be negative, which is useful
+// for accounting models with power credits. Negative values propagate to power
+// profiles and energy as is, and peaks are the largest values, not the largest
+// magnitudes.
 package dynamic
 
 import (
+	"math"
+	"math/rand"
+
 	"github.com/ready-steady/sort"
 	"github.com/turing-complete/system"
 	"github.com/turing-complete/time"
@@ -11,72 +19,885 @@ import (
 type Power struct {
 	platform    *system.Platform
 	application *system.Application
+
+	model    Model
+	activity []float64
+	scaling  []float64
+	floor    float64
+	rtol     float64
+	static   []float64
+	domains  []uint
+	leakage  []float64
+	idle     func(uint, float64) float64
+	variance []float64
+	sampler  Sampler
+	phases   [][]Phase
+
+	workers  uint
+	pool     *pool
+	boundary Boundary
 }
 
+// Boundary is a convention for deciding whether a task is active at the
+// boundaries of its execution interval.
+type Boundary uint
+
+const (
+	// Closed treats a task as active on [start, finish].
+	Closed Boundary = iota
+	// HalfOpen treats a task as active on [start, finish).
+	HalfOpen
+)
+
 // New returns a power calculator.
-func New(platform *system.Platform, application *system.Application) *Power {
-	return &Power{platform: platform, application: application}
+func New(platform *system.Platform, application *system.Application,
+	options ...Option) *Power {
+
+	power := &Power{platform: platform, application: application}
+	for _, option := range options {
+		option(power)
+	}
+	if power.model == nil {
+		power.model = &table{platform: platform, application: application}
+	}
+	if power.leakage != nil {
+		static := make([]float64, len(power.domains))
+		copy(static, power.static)
+		for j, k := range power.domains {
+			static[j] += power.leakage[k]
+		}
+		power.static = static
+	}
+	return power
 }
 
 // Distribute returns the power consumption of the tasks.
-func (self *Power) Distribute(schedule *time.Schedule) []float64 {
-	cores, tasks := self.platform.Cores, self.application.Tasks
-	power := make([]float64, self.application.Len())
+func (self *Power) Distribute(schedule *time.Schedule) PowerVector {
+	power := make(PowerVector, self.application.Len())
+	self.DistributeInto(schedule, power)
+	return power
+}
+
+// DistributeInto is the same as Distribute except that the result is written
+// to out, which should be preallocated for all the tasks.
+func (self *Power) DistributeInto(schedule *time.Schedule, out []float64) {
 	for i, j := range schedule.Mapping {
-		power[i] = cores[j].Power[tasks[i].Type]
+		out[i] = self.lookup(j, uint(i))
 	}
+}
+
+// DistributeRandom is the same as Distribute except that the power consumption
+// of each task is perturbed by Gaussian noise drawn from a random number
+// generator. The noise is relative: sigma[i] is the standard deviation of the
+// power consumption of task i divided by its mean, which is the power
+// consumption computed by Distribute. The result is hence distributed as a
+// realization drawn with WithPowerVariance whose variance[i] is the square of
+// sigma[i] times the mean. If sigma is nil, the result is the same as the one
+// of Distribute, and no numbers are drawn.
+func (self *Power) DistributeRandom(schedule *time.Schedule, rng *rand.Rand,
+	sigma []float64) PowerVector {
+
+	power := self.Distribute(schedule)
+	if sigma == nil {
+		return power
+	}
+	deviation := make([]float64, len(power))
+	for i := range power {
+		deviation[i] = sigma[i] * power[i]
+	}
+	perturb(power, deviation, rng)
 	return power
 }
 
+// DistributeSubset is the same as Distribute except that the power consumption
+// is computed only for the tasks with the given indices. The result is written
+// to the corresponding positions of out, which should be preallocated for all
+// the tasks; the rest of the positions are left untouched.
+func (self *Power) DistributeSubset(schedule *time.Schedule, indices []uint, out []float64) {
+	for _, i := range indices {
+		out[i] = self.lookup(schedule.Mapping[i], i)
+	}
+}
+
 // Partition computes a power profile with a variable time step dictated by the
 // time moments of power switches.
 func (self *Power) Partition(schedule *time.Schedule, ε float64) ([]float64, []float64) {
-	return partition(self.Distribute(schedule), schedule, ε)
+	return self.PartitionInto(schedule, ε, nil, nil)
+}
+
+// PartitionInto is the same as Partition except that the power profile and the
+// time steps are written to P and ΔT, respectively, provided they have enough
+// capacity; otherwise, new slices are allocated. The outputs are the two
+// slices resized to the actual number of steps. The buffers are overwritten
+// entirely; hence, the outputs of one call can be passed to the next one
+// without clearing them. Only the storage of the outputs is reused; the
+// intermediate quantities, whose sizes are proportional to the number of
+// tasks, such as the sorted time moments, are allocated on each call.
+func (self *Power) PartitionInto(schedule *time.Schedule, ε float64,
+	P, ΔT []float64) ([]float64, []float64) {
+
+	power, schedule := self.prune(schedule)
+	return self.partitionInto(power, schedule, ε, P, ΔT)
+}
+
+func (self *Power) partitionInto(power []float64, schedule *time.Schedule, ε float64,
+	P, ΔT []float64) ([]float64, []float64) {
+
+	ΔT, ssteps, fsteps := divideInto(schedule, ε, self.rtol, ΔT)
+	ns := uint(len(ΔT))
+	P = resize(P, schedule.Cores*ns)
+	fill(P, power, schedule, ssteps, fsteps, self.parallel())
+	self.superimpose(P, schedule.Cores, ns)
+	if self.idle != nil {
+		self.superimposeIdle(P, schedule, moments(schedule, ΔT)[:ns], ssteps, fsteps)
+	}
+	return P, ΔT
+}
+
+// PartitionSorted is the same as Partition except that it assumes the start
+// times and the finish times of the tasks to be individually non-decreasing,
+// which allows for avoiding sorting. If the assumption does not hold, the
+// function falls back to Partition.
+func (self *Power) PartitionSorted(schedule *time.Schedule, ε float64) ([]float64, []float64) {
+	power, schedule := self.prune(schedule)
+	ΔT, ssteps, fsteps := divideSorted(schedule, ε, self.rtol)
+	P := make([]float64, schedule.Cores*uint(len(ΔT)))
+	fill(P, power, schedule, ssteps, fsteps, self.parallel())
+	self.superimpose(P, schedule.Cores, uint(len(ΔT)))
+	if self.idle != nil {
+		self.superimposeIdle(P, schedule, moments(schedule, ΔT)[:len(ΔT)], ssteps, fsteps)
+	}
+	return P, ΔT
+}
+
+// PartitionAveraged is the same as Partition except that the power consumption
+// of each core at each time step is averaged over the duration of the step.
+// Partition assigns a task to the steps between the ones its start and finish
+// times are merged into, which changes the energy of short tasks when ε is
+// large; by contrast, PartitionAveraged accounts for the exact overlap of each
+// task with each step. The last step is extended to the latest finish time if
+// the latter is merged into an earlier time moment, and the idle power, if set,
+// is weighted by the fraction of each step during which the core is not
+// running any tasks. Consequently, the energy is preserved provided that the
+// tasks mapped onto the same core do not overlap and the idle power is
+// constant. The two coincide up to rounding when no merging takes place.
+func (self *Power) PartitionAveraged(schedule *time.Schedule, ε float64) ([]float64, []float64) {
+	power, schedule := self.prune(schedule)
+
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	ΔT, ssteps, fsteps := divide(schedule, ε, self.rtol)
+	ns := uint(len(ΔT))
+
+	time := moments(schedule, ΔT)
+	if ns > 0 {
+		finish := time[ns]
+		for i := uint(0); i < nt; i++ {
+			finish = math.Max(finish, schedule.Finish[i])
+		}
+		ΔT[ns-1] += finish - time[ns]
+		time[ns] = finish
+	}
+
+	P := make([]float64, nc*ns)
+	busy := make([]float64, nc*ns)
+	for i := uint(0); i < nt; i++ {
+		j, p := schedule.Mapping[i], power[i]
+		s, f := schedule.Start[i], schedule.Finish[i]
+		for k := ssteps[i]; k <= fsteps[i] && k < ns; k++ {
+			if δ := math.Min(f, time[k+1]) - math.Max(s, time[k]); δ > 0 {
+				P[k*nc+j] += p * δ
+				busy[k*nc+j] += δ
+			}
+		}
+	}
+	for k := uint(0); k < ns; k++ {
+		for j := uint(0); j < nc; j++ {
+			if self.idle != nil {
+				if δ := ΔT[k] - busy[k*nc+j]; δ > 0 {
+					P[k*nc+j] += self.idle(j, time[k]) * δ
+				}
+			}
+			P[k*nc+j] /= ΔT[k]
+		}
+	}
+
+	self.superimpose(P, nc, ns)
+
+	return P, ΔT
+}
+
+// PartitionTotalOnly computes the total power consumption of all cores with
+// the same variable time step as Partition does. The per-core power profile is
+// not constructed.
+func (self *Power) PartitionTotalOnly(schedule *time.Schedule, ε float64) ([]float64, []float64) {
+	power, schedule := self.prune(schedule)
+	return self.totals(power, schedule, ε)
 }
 
 // Sample computes a power profile with respect to a sampling interval Δt.
 //
 // The required number of samples is specified by ns; short schedules are
-// extended while long ones are truncated.
-func (self *Power) Sample(schedule *time.Schedule, Δt float64, ns uint) []float64 {
-	return sample(self.Distribute(schedule), schedule, Δt, ns)
+// extended while long ones are truncated. The second output is the number of
+// samples that are covered by the schedule, which is at most ns.
+func (self *Power) Sample(schedule *time.Schedule, Δt float64, ns uint) ([]float64, uint) {
+	P := make([]float64, schedule.Cores*ns)
+	power, schedule := self.prune(schedule)
+	return P, self.sampleInto(schedule, power, Δt, ns, P)
+}
+
+// SampleInto is the same as Sample except that the power profile is written to
+// out, which should have room for at least nc*ns elements, and only the number
+// of covered samples is returned. The first nc*ns elements of out are zeroed
+// before the profile is computed; hence, a buffer can be reused between calls
+// without clearing it.
+func (self *Power) SampleInto(schedule *time.Schedule, Δt float64, ns uint, out []float64) uint {
+	P := out[:schedule.Cores*ns]
+	for i := range P {
+		P[i] = 0
+	}
+	power, schedule := self.prune(schedule)
+	return self.sampleInto(schedule, power, Δt, ns, P)
+}
+
+func (self *Power) sampleInto(schedule *time.Schedule, power []float64, Δt float64,
+	ns uint, P []float64) uint {
+
+	ns = fillSample(P, power, schedule, Δt, ns, self.parallel())
+	self.superimpose(P, schedule.Cores, ns)
+	if self.idle != nil {
+		nt := schedule.Tasks
+		time := make([]float64, ns)
+		for k := range time {
+			time[k] = float64(k) * Δt
+		}
+		ssteps, fsteps := make([]uint, nt), make([]uint, nt)
+		for i := uint(0); i < nt; i++ {
+			ssteps[i], fsteps[i] = grid(schedule, i, Δt, ns)
+		}
+		self.superimposeIdle(P, schedule, time, ssteps, fsteps)
+	}
+	return ns
 }
 
 // Progress returns a function for computing the power consumption at an
 // arbitrary time moment.
+//
+// Whether a task is active at its start and finish times is dictated by the
+// boundary convention, which is Closed by default; see SetBoundary.
 func (self *Power) Progress(schedule *time.Schedule) func(float64, []float64) {
-	return progress(self.Distribute(schedule), schedule)
+	power, schedule := self.prune(schedule)
+	compute := progress(power, schedule, self.boundary)
+	if self.static == nil && self.idle == nil {
+		return compute
+	}
+
+	nc, span := schedule.Cores, schedule.Span
+
+	mapping := group(schedule)
+	active := activity(schedule, self.boundary)
+	busy := func(j uint, time float64) bool {
+		for _, i := range mapping[j] {
+			if active(i, time) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(time float64, result []float64) {
+		compute(time, result)
+		if time < 0 || time > span {
+			return
+		}
+		for j := uint(0); j < nc; j++ {
+			if self.static != nil {
+				result[j] += self.static[j]
+			}
+			if self.idle != nil && !busy(j, time) {
+				result[j] += self.idle(j, time)
+			}
+		}
+	}
 }
 
-func partition(power []float64, schedule *time.Schedule, ε float64) ([]float64, []float64) {
-	nc, nt := schedule.Cores, schedule.Tasks
+// ProgressChanged is the same as Progress except that the returned function
+// also reports which cores changed their power consumption since the previous
+// call. The function sets changed[j] to whether the power consumption of core
+// j differs from the one computed at the previous call; at the first call, all
+// cores are reported as changed.
+func (self *Power) ProgressChanged(schedule *time.Schedule) func(float64, []float64, []bool) {
+	compute := self.Progress(schedule)
+	last, first := make([]float64, schedule.Cores), true
+	return func(time float64, result []float64, changed []bool) {
+		compute(time, result)
+		for j := range last {
+			changed[j] = first || result[j] != last[j]
+			last[j] = result[j]
+		}
+		first = false
+	}
+}
+
+// SampleLog evaluates the power consumption of the cores as Progress does at ns
+// time moments spaced logarithmically from t0 to the span of the schedule,
+// where t0 should be positive. The outputs are the power profile, stored in
+// the same layout as the one of Sample, and the time moments. Since the time
+// moments are not equidistant, integration over the profile should be done
+// with respect to the returned time moments.
+func (self *Power) SampleLog(schedule *time.Schedule, t0 float64, ns uint) ([]float64, []float64) {
+	times := make([]float64, ns)
+	if ns == 1 {
+		times[0] = t0
+	} else if ns > 1 {
+		ratio := math.Log(schedule.Span / t0)
+		for k := uint(0); k < ns; k++ {
+			times[k] = t0 * math.Exp(ratio*float64(k)/float64(ns-1))
+		}
+		times[0], times[ns-1] = t0, schedule.Span
+	}
+
+	return self.ProgressMatrix(schedule, times), times
+}
+
+// ActiveTasks returns a function for finding the tasks that are active at an
+// arbitrary time moment. The function fills out[j] with the indices of all the
+// tasks active on core j, reusing the underlying arrays. Whether a task is
+// active at the boundaries of its execution interval is decided as in Progress.
+func (self *Power) ActiveTasks(schedule *time.Schedule) func(float64, [][]uint) {
+	mapping := group(schedule)
+	active := activity(schedule, self.boundary)
+
+	return func(time float64, out [][]uint) {
+		for i := range mapping {
+			out[i] = out[i][:0]
+			for _, j := range mapping[i] {
+				if active(j, time) {
+					out[i] = append(out[i], j)
+				}
+			}
+		}
+	}
+}
+
+// ProgressEMA is the same as Progress except that the returned function
+// smooths the power consumption of each core with an exponential moving
+// average. At each call, the result is alpha times the instantaneous power
+// consumption plus (1 - alpha) times the result of the previous call, which is
+// taken to be zero before the first call. The smoothing is per call, and the
+// function is intended for being invoked at a fixed time interval; alpha = 1
+// disables the smoothing.
+func (self *Power) ProgressEMA(schedule *time.Schedule, alpha float64) func(float64, []float64) {
+	compute := self.Progress(schedule)
+	last := make([]float64, schedule.Cores)
+	return func(time float64, result []float64) {
+		compute(time, result)
+		for j := range last {
+			result[j] = alpha*result[j] + (1-alpha)*last[j]
+			last[j] = result[j]
+		}
+	}
+}
+
+// ProgressMatrix evaluates the power consumption of the cores at the given
+// time moments as Progress does. The result is stored in a time-major layout:
+// the power consumption of core j at time moment k is at index k*nc+j.
+func (self *Power) ProgressMatrix(schedule *time.Schedule, times []float64) []float64 {
+	nc := schedule.Cores
+
+	compute := self.Progress(schedule)
+
+	P := make([]float64, uint(len(times))*nc)
+	for k, time := range times {
+		compute(time, P[uint(k)*nc:uint(k+1)*nc])
+	}
+
+	return P
+}
+
+// ProgressMatrixByCore is the same as ProgressMatrix except that the result is
+// stored in a core-major layout: the power consumption of core j at time moment
+// k is at index j*len(times)+k.
+func (self *Power) ProgressMatrixByCore(schedule *time.Schedule, times []float64) []float64 {
+	nc, nk := schedule.Cores, uint(len(times))
+
+	compute := self.Progress(schedule)
+
+	P, row := make([]float64, nk*nc), make([]float64, nc)
+	for k, time := range times {
+		compute(time, row)
+		for j := uint(0); j < nc; j++ {
+			P[j*nk+uint(k)] = row[j]
+		}
+	}
+
+	return P
+}
+
+// SetBoundary sets the boundary convention used by Progress.
+func (self *Power) SetBoundary(boundary Boundary) {
+	self.boundary = boundary
+}
+
+// PowerAtTaskBoundary returns the power consumption of the cores at the start
+// or finish time of a task as computed by Progress.
+func (self *Power) PowerAtTaskBoundary(schedule *time.Schedule, task uint, atStart bool) []float64 {
+	time := schedule.Finish[task]
+	if atStart {
+		time = schedule.Start[task]
+	}
+	result := make([]float64, schedule.Cores)
+	self.Progress(schedule)(time, result)
+	return result
+}
+
+// lookup returns the power consumption of a task on a core.
+func (self *Power) lookup(core, task uint) float64 {
+	p := self.model.Power(core, task)
+	if self.activity != nil {
+		p *= self.activity[task]
+	}
+	if self.scaling != nil {
+		p *= self.scaling[task]
+	}
+	if math.Abs(p) < self.floor {
+		return 0
+	}
+	return p
+}
+
+func (self *Power) totals(power []float64, schedule *time.Schedule,
+	ε float64) ([]float64, []float64) {
+
+	P, ΔT, ssteps, fsteps := partitionTotal(power, schedule, ε, self.rtol)
+	if self.static != nil {
+		static := sum(self.static)
+		for i := range P {
+			P[i] += static
+		}
+	}
+	if self.idle == nil {
+		return P, ΔT
+	}
+
+	ns := uint(len(ΔT))
+	events, offset := arrange(ssteps, fsteps, ns)
+	time := moments(schedule, ΔT)
+	busy := make([]int, schedule.Cores)
+	for k, l := uint(0), uint(0); k < ns; k++ {
+		m := offset[k]
+		occupy(busy, schedule, events[l:m])
+		l = m
+		for j, count := range busy {
+			if count == 0 {
+				P[k] += self.idle(uint(j), time[k])
+			}
+		}
+	}
+
+	return P, ΔT
+}
+
+// levels returns the total power consumption of all cores over the span of a
+// schedule and the durations over which it stays constant in chronological
+// order: the part of the span preceding the time steps of Partition, the time
+// steps, and the part following them. Parts of zero duration are omitted.
+func (self *Power) levels(power []float64, schedule *time.Schedule,
+	ε float64) ([]float64, []float64) {
+
+	totals, ΔT := self.totals(power, schedule, ε)
+
+	start := origin(schedule)
+	finish := start + sum(ΔT)
+
+	levels := make([]float64, 0, len(totals)+2)
+	durations := make([]float64, 0, len(ΔT)+2)
+	if start > 0 {
+		levels = append(levels, self.rest(schedule.Cores, 0))
+		durations = append(durations, start)
+	}
+	levels = append(levels, totals...)
+	durations = append(durations, ΔT...)
+	if Δ := schedule.Span - finish; Δ > 0 {
+		levels = append(levels, self.rest(schedule.Cores, finish))
+		durations = append(durations, Δ)
+	}
+
+	return levels, durations
+}
+
+// outside returns the energy consumption of all cores over the parts of the
+// span of a schedule that precede and follow the time steps of Partition, where
+// no tasks are running. The total duration of the time steps is given by
+// duration.
+func (self *Power) outside(schedule *time.Schedule, duration float64) float64 {
+	nc := schedule.Cores
+
+	start := origin(schedule)
+	finish := start + duration
+
+	energy := 0.0
+	if start > 0 {
+		energy += self.rest(nc, 0) * start
+	}
+	if Δ := schedule.Span - finish; Δ > 0 {
+		energy += self.rest(nc, finish) * Δ
+	}
+
+	return energy
+}
+
+// rest returns the total power consumption of all cores at a time moment when
+// none of them is running any tasks, which is the sum of the static and idle
+// power consumption.
+func (self *Power) rest(nc uint, time float64) float64 {
+	total := 0.0
+	for j := uint(0); j < nc; j++ {
+		total += self.resting(j, time)
+	}
+	return total
+}
+
+// resting returns the power consumption of a core at a time moment when it is
+// not running any tasks.
+func (self *Power) resting(j uint, time float64) float64 {
+	p := 0.0
+	if self.static != nil {
+		p += self.static[j]
+	}
+	if self.idle != nil {
+		p += self.idle(j, time)
+	}
+	return p
+}
+
+// idleEnergy returns the idle energy consumption of each core within the time
+// interval [t0, t1). The idle power is integrated piecewise as described in
+// idling.
+func (self *Power) idleEnergy(schedule *time.Schedule, t0, t1 float64) []float64 {
+	energy := make([]float64, schedule.Cores)
+	if self.idle == nil {
+		return energy
+	}
+	for j, pieces := range self.idling(schedule, gaps(schedule, 0)) {
+		for _, piece := range pieces {
+			s, f := math.Max(piece.start, t0), math.Min(piece.finish, t1)
+			if f > s {
+				energy[j] += piece.power * (f - s)
+			}
+		}
+	}
+	return energy
+}
+
+// piece is a part of an idle interval of a core over which the idle power
+// consumption is considered constant.
+type piece struct {
+	start  float64
+	finish float64
+	power  float64
+}
+
+// idling splits the idle intervals of each core at the time moments of the
+// time steps of Partition with ε equal to zero and evaluates the idle power at
+// the start of each part, which is what the profiles of Partition do; hence,
+// the idle energy agrees with them even if the idle power varies over time.
+func (self *Power) idling(schedule *time.Schedule, intervals [][]gap) [][]piece {
+	ΔT, _, _ := divide(schedule, 0, self.rtol)
+	time := moments(schedule, ΔT)
+
+	result := make([][]piece, len(intervals))
+	for j := range intervals {
+		for _, gap := range intervals[j] {
+			k, upper := 0, len(time)
+			for k < upper {
+				if middle := (k + upper) / 2; time[middle] <= gap.start {
+					k = middle + 1
+				} else {
+					upper = middle
+				}
+			}
+			for start := gap.start; start < gap.finish; {
+				for k < len(time) && time[k] <= start {
+					k++
+				}
+				finish := gap.finish
+				if k < len(time) && time[k] < finish {
+					finish = time[k]
+				}
+				result[j] = append(result[j], piece{
+					start:  start,
+					finish: finish,
+					power:  self.idle(uint(j), start),
+				})
+				start = finish
+			}
+		}
+	}
+
+	return result
+}
+
+// superimpose adds the static power consumption of the cores to the first ns
+// steps of a power profile.
+func (self *Power) superimpose(P []float64, nc, ns uint) {
+	if self.static == nil {
+		return
+	}
+	for i := uint(0); i < ns; i++ {
+		for j := uint(0); j < nc; j++ {
+			P[i*nc+j] += self.static[j]
+		}
+	}
+}
+
+// superimposeIdle adds the idle power consumption to the cells of a power
+// profile where the cores are not running any tasks. The time moments at which
+// the steps start are given by time, and each task occupies the steps from its
+// start step up to but excluding its finish step.
+func (self *Power) superimposeIdle(P []float64, schedule *time.Schedule, time []float64,
+	ssteps, fsteps []uint) {
+
+	if self.idle == nil {
+		return
+	}
+
+	nc, nt, ns := schedule.Cores, schedule.Tasks, uint(len(time))
+
+	busy := make([]bool, nc*ns)
+	for i := uint(0); i < nt; i++ {
+		j := schedule.Mapping[i]
+		for s := ssteps[i]; s < fsteps[i] && s < ns; s++ {
+			busy[s*nc+j] = true
+		}
+	}
+
+	for k := uint(0); k < ns; k++ {
+		for j := uint(0); j < nc; j++ {
+			if !busy[k*nc+j] {
+				P[k*nc+j] += self.idle(j, time[k])
+			}
+		}
+	}
+}
+
+// moments returns the time moments at which the time steps of Partition start
+// followed by the time moment at which the last one finishes.
+func moments(schedule *time.Schedule, ΔT []float64) []float64 {
+	ns := uint(len(ΔT))
+
+	time := make([]float64, ns+1)
+	time[0] = origin(schedule)
+	for k := uint(0); k < ns; k++ {
+		time[k+1] = time[k] + ΔT[k]
+	}
+
+	return time
+}
+
+// prune distributes the power consumption of the tasks, splits the tasks into
+// their phases, if any, and, if a power floor is set, excludes the tasks that
+// are idle so that they do not give rise to additional time steps.
+func (self *Power) prune(schedule *time.Schedule) ([]float64, *time.Schedule) {
+	return self.exclude(self.split(self.Distribute(schedule), schedule))
+}
+
+// exclude removes the tasks with no power consumption if a power floor is set.
+func (self *Power) exclude(power []float64, schedule *time.Schedule) ([]float64, *time.Schedule) {
+	if self.floor <= 0 {
+		return power, schedule
+	}
+
+	nt := schedule.Tasks
+
+	result := *schedule
+	result.Tasks = 0
+	result.Mapping = make([]uint, 0, nt)
+	result.Start = make([]float64, 0, nt)
+	result.Finish = make([]float64, 0, nt)
+
+	active := make([]float64, 0, nt)
+	for i := uint(0); i < nt; i++ {
+		if power[i] == 0 {
+			continue
+		}
+		result.Tasks++
+		result.Mapping = append(result.Mapping, schedule.Mapping[i])
+		result.Start = append(result.Start, schedule.Start[i])
+		result.Finish = append(result.Finish, schedule.Finish[i])
+		active = append(active, power[i])
+	}
+
+	return active, &result
+}
+
+func divide(schedule *time.Schedule, ε, rtol float64) ([]float64, []uint, []uint) {
+	return divideInto(schedule, ε, rtol, nil)
+}
+
+// divideInto is the same as divide except that the time steps are written to
+// the storage of ΔT when its capacity suffices.
+func divideInto(schedule *time.Schedule, ε, rtol float64,
+	ΔT []float64) ([]float64, []uint, []uint) {
+
+	nt := schedule.Tasks
+	if nt == 0 {
+		return ΔT[:0], nil, nil
+	}
+
+	ΔT, steps := traverseInto(boundaries(schedule), ε, rtol, ΔT)
+
+	return compact(ΔT, steps[:nt], steps[nt:])
+}
+
+func divideSorted(schedule *time.Schedule, ε, rtol float64) ([]float64, []uint, []uint) {
+	nt := schedule.Tasks
+	start, finish := schedule.Start, schedule.Finish
+
+	if nt == 0 || !sorted(start[:nt]) || !sorted(finish[:nt]) {
+		return divide(schedule, ε, rtol)
+	}
+
+	Δ := make([]float64, 0, 2*nt-1)
+	ssteps, fsteps := make([]uint, nt), make([]uint, nt)
+
+	j, x := uint(0), math.Min(start[0], finish[0])
+
+	for s, f := uint(0), uint(0); s < nt || f < nt; {
+		var point float64
+		var steps []uint
+		var k uint
+		if s < nt && (f == nt || start[s] <= finish[f]) {
+			point, steps, k = start[s], ssteps, s
+			s++
+		} else {
+			point, steps, k = finish[f], fsteps, f
+			f++
+		}
+		if δ := point - x; apart(x, point, ε, rtol) {
+			x = point
+			Δ = append(Δ, δ)
+			j++
+		}
+		steps[k] = j
+	}
+
+	return compact(Δ, ssteps, fsteps)
+}
+
+// boundaries returns the start times of the tasks followed by the finish
+// times of the tasks.
+func boundaries(schedule *time.Schedule) []float64 {
+	nt := schedule.Tasks
 
 	time := make([]float64, 2*nt)
 	copy(time[:nt], schedule.Start)
 	copy(time[nt:], schedule.Finish)
 
-	ΔT, steps := traverse(time, ε)
-	ssteps, fsteps := steps[:nt], steps[nt:2*nt]
+	return time
+}
 
+// compact removes the time steps whose durations are not positive, which can
+// happen only when ε is negative, and renumbers the steps of the tasks.
+func compact(ΔT []float64, ssteps, fsteps []uint) ([]float64, []uint, []uint) {
 	ns := uint(len(ΔT))
 
-	P := make([]float64, nc*ns)
+	index := make([]uint, ns+1)
+	k := uint(0)
+	for i := uint(0); i < ns; i++ {
+		index[i] = k
+		if ΔT[i] > 0 {
+			ΔT[k] = ΔT[i]
+			k++
+		}
+	}
+	if k == ns {
+		return ΔT, ssteps, fsteps
+	}
+	index[ns] = k
 
-	for i := uint(0); i < nt; i++ {
+	for i := range ssteps {
+		ssteps[i] = index[ssteps[i]]
+		fsteps[i] = index[fsteps[i]]
+	}
+
+	return ΔT[:k], ssteps, fsteps
+}
+
+func fill(P, power []float64, schedule *time.Schedule,
+	ssteps, fsteps []uint, pool *pool) {
+
+	nc := schedule.Cores
+
+	dispatch(schedule, pool, func(i uint) {
 		j := schedule.Mapping[i]
 		p := power[i]
 
 		s, f := ssteps[i], fsteps[i]
 
 		for ; s < f; s++ {
-			P[s*nc+j] = p
+			P[s*nc+j] += p
 		}
+	})
+
+}
+
+func resize(buffer []float64, n uint) []float64 {
+	if uint(cap(buffer)) < n {
+		return make([]float64, n)
+	}
+	buffer = buffer[:n]
+	for i := range buffer {
+		buffer[i] = 0
 	}
+	return buffer
+}
 
-	return P, ΔT
+func partitionTotal(power []float64, schedule *time.Schedule,
+	ε, rtol float64) ([]float64, []float64, []uint, []uint) {
+
+	nt := schedule.Tasks
+
+	ΔT, ssteps, fsteps := divide(schedule, ε, rtol)
+
+	P := make([]float64, len(ΔT))
+
+	for i := uint(0); i < nt; i++ {
+		p := power[i]
+
+		s, f := ssteps[i], fsteps[i]
+
+		for ; s < f; s++ {
+			P[s] += p
+		}
+	}
+
+	return P, ΔT, ssteps, fsteps
 }
 
-func progress(power []float64, schedule *time.Schedule) func(float64, []float64) {
+func progress(power []float64, schedule *time.Schedule,
+	boundary Boundary) func(float64, []float64) {
+
+	nc := schedule.Cores
+
+	mapping := group(schedule)
+	active := activity(schedule, boundary)
+
+	return func(time float64, result []float64) {
+		for i := uint(0); i < nc; i++ {
+			result[i] = 0
+			for _, j := range mapping[i] {
+				if active(j, time) {
+					result[i] = power[j]
+					break
+				}
+			}
+		}
+	}
+}
+
+// group returns the indices of the tasks mapped onto each core.
+func group(schedule *time.Schedule) [][]uint {
 	nc, nt := schedule.Cores, schedule.Tasks
 
 	mapping := make([][]uint, nc)
@@ -89,65 +910,105 @@ func progress(power []float64, schedule *time.Schedule) func(float64, []float64)
 		}
 	}
 
+	return mapping
+}
+
+// activity returns a function checking if a task is active at a time moment
+// according to a boundary convention.
+func activity(schedule *time.Schedule, boundary Boundary) func(uint, float64) bool {
 	start, finish := schedule.Start, schedule.Finish
 
-	return func(time float64, result []float64) {
-		for i := uint(0); i < nc; i++ {
-			result[i] = 0
-			for _, j := range mapping[i] {
-				if start[j] <= time && time <= finish[j] {
-					result[i] = power[j]
-					break
-				}
-			}
+	if boundary == HalfOpen {
+		return func(j uint, time float64) bool {
+			return start[j] <= time && time < finish[j]
 		}
 	}
+	return func(j uint, time float64) bool {
+		return start[j] <= time && time <= finish[j]
+	}
 }
 
-func sample(power []float64, schedule *time.Schedule, Δt float64, ns uint) []float64 {
-	nc, nt := schedule.Cores, schedule.Tasks
+// fillSample adds the power consumption of the tasks to a preallocated power
+// profile with respect to a sampling interval Δt and returns the number of
+// samples covered by the schedule.
+func fillSample(P, power []float64, schedule *time.Schedule, Δt float64, ns uint,
+	pool *pool) uint {
 
-	P := make([]float64, nc*ns)
+	nc := schedule.Cores
 
 	if count := uint(schedule.Span / Δt); count < ns {
 		ns = count
 	}
 
-	for i := uint(0); i < nt; i++ {
+	dispatch(schedule, pool, func(i uint) {
 		j := schedule.Mapping[i]
 		p := power[i]
 
-		s := uint(schedule.Start[i]/Δt + 0.5)
-		f := uint(schedule.Finish[i]/Δt + 0.5)
-		if f > ns {
-			f = ns
-		}
+		s, f := grid(schedule, i, Δt, ns)
 
 		for ; s < f; s++ {
-			P[s*nc+j] = p
+			P[s*nc+j] += p
 		}
+	})
+
+	return ns
+}
+
+// grid returns the range of samples occupied by a task.
+func grid(schedule *time.Schedule, i uint, Δt float64, ns uint) (uint, uint) {
+	s := uint(schedule.Start[i]/Δt + 0.5)
+	f := uint(schedule.Finish[i]/Δt + 0.5)
+	if f > ns {
+		f = ns
 	}
+	return s, f
+}
 
-	return P
+func sorted(data []float64) bool {
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			return false
+		}
+	}
+	return true
 }
 
-func traverse(points []float64, ε float64) ([]float64, []uint) {
+func traverse(points []float64, ε, rtol float64) ([]float64, []uint) {
+	return traverseInto(points, ε, rtol, nil)
+}
+
+// traverseInto is the same as traverse except that the durations are appended
+// to the storage of Δ, which is allocated only if Δ has no capacity or runs out
+// of it.
+func traverseInto(points []float64, ε, rtol float64, Δ []float64) ([]float64, []uint) {
 	np := uint(len(points))
 	order, _ := sort.Quick(points)
 
-	Δ := make([]float64, np-1)
+	if cap(Δ) == 0 {
+		Δ = make([]float64, 0, np-1)
+	}
+	Δ = Δ[:0]
 	steps := make([]uint, np)
 
 	j := uint(0)
 
 	for i, x := uint(1), points[0]; i < np; i++ {
-		if δ := points[i] - x; δ > ε {
+		if δ := points[i] - x; apart(x, points[i], ε, rtol) {
 			x = points[i]
-			Δ[j] = δ
+			Δ = append(Δ, δ)
 			j++
 		}
 		steps[order[i]] = j
 	}
 
-	return Δ[:j], steps
+	return Δ, steps
+}
+
+// apart checks if a point should start a new time step after the group of
+// points represented by x. The point is merged into the group if it is within
+// the absolute tolerance ε or, when the relative tolerance rtol is positive,
+// within rtol times the larger of the two magnitudes.
+func apart(x, point, ε, rtol float64) bool {
+	δ := point - x
+	return δ > ε && (rtol <= 0 || δ > rtol*math.Max(math.Abs(x), math.Abs(point)))
 }
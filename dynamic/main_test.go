@@ -2,6 +2,8 @@ package dynamic
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"path"
 	"testing"
 
@@ -10,6 +12,29 @@ import (
 	"github.com/turing-complete/time"
 )
 
+func TestDistributeSubset(t *testing.T) {
+	power, schedule := prepare("002_040")
+	full := power.Distribute(schedule)
+
+	indices := []uint{0, 7, 21, 39}
+
+	out := make([]float64, len(full))
+	for i := range out {
+		out[i] = -1
+	}
+	power.DistributeSubset(schedule, indices, out)
+
+	for i := range out {
+		expected := -1.0
+		for _, j := range indices {
+			if uint(i) == j {
+				expected = full[i]
+			}
+		}
+		assert.Equal(out[i], expected, t)
+	}
+}
+
 func TestPartition(t *testing.T) {
 	const (
 		n = 10
@@ -23,6 +48,145 @@ func TestPartition(t *testing.T) {
 	assert.Close(ΔT, fixturePartition.ΔT, 1e-15, t)
 }
 
+func TestDistributeRandom(t *testing.T) {
+	power, schedule := prepare("002_040")
+	nt := schedule.Tasks
+
+	expected := power.Distribute(schedule)
+
+	sigma := make([]float64, nt)
+	assert.Equal(power.DistributeRandom(schedule, rand.New(rand.NewSource(0)), sigma), expected, t)
+	assert.Equal(power.DistributeRandom(schedule, nil, nil), expected, t)
+
+	for i := range sigma {
+		sigma[i] = 0.1
+	}
+	P1 := power.DistributeRandom(schedule, rand.New(rand.NewSource(42)), sigma)
+	P2 := power.DistributeRandom(schedule, rand.New(rand.NewSource(42)), sigma)
+	assert.Equal(P1, P2, t)
+
+	different := false
+	for i := range P1 {
+		different = different || P1[i] != expected[i]
+	}
+	assert.Equal(different, true, t)
+}
+
+func TestPartitionCompact(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 3}, {4, 5}},
+		[]uint{0, 1, 0, 1}, []uint{0, 0, 1, 1},
+		[]float64{0, 1, 0, 1}, []float64{1, 2, 1, 3})
+
+	times := []float64{0, 1, 0, 1, 1, 2, 1, 3}
+	ΔT, _ := traverse(times, -1, 0)
+	assert.Equal(ΔT, []float64{0, 1, 0, 0, 0, 1, 1}, t)
+
+	P, ΔT := power.Partition(schedule, -1)
+	assert.Equal(P, []float64{2, 4, 3, 5, 0, 5}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	P, ΔT = power.PartitionSorted(schedule, -1)
+	assert.Equal(P, []float64{2, 4, 3, 5, 0, 5}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+}
+
+func TestPartitionSorted(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+	P, ΔT := power.PartitionSorted(schedule, ε)
+	assert.Equal(P, fixturePartition.P, t)
+	assert.Close(ΔT, fixturePartition.ΔT, 1e-15, t)
+
+	power, schedule = prepareSorted(4, 100)
+	P1, ΔT1 := power.Partition(schedule, ε)
+	P2, ΔT2 := power.PartitionSorted(schedule, ε)
+	assert.Equal(P2, P1, t)
+	assert.Equal(ΔT2, ΔT1, t)
+}
+
+func TestPartitionAveraged(t *testing.T) {
+	const (
+		ε = 1
+	)
+
+	power, schedule := prepareCustom([][]float64{{1, 100}, {1, 100}},
+		[]uint{0, 0, 1}, []uint{0, 0, 1},
+		[]float64{0, 10.5, 10}, []float64{10, 20, 10.5})
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{1, 0, 1, 0}, t)
+	assert.Equal(ΔT, []float64{10, 10}, t)
+
+	P, ΔT = power.PartitionAveraged(schedule, ε)
+	assert.Close(P, []float64{1, 0, 0.95, 5}, 1e-15, t)
+	assert.Equal(ΔT, []float64{10, 10}, t)
+
+	power, schedule = prepare("002_040")
+
+	P1, ΔT1 := power.Partition(schedule, 1e-14)
+	P2, ΔT2 := power.PartitionAveraged(schedule, 1e-14)
+	assert.Close(P2, P1, 1e-10, t)
+	assert.Equal(ΔT2, ΔT1, t)
+
+	power, schedule = prepareCustom([][]float64{{1, 100}, {1, 100}},
+		[]uint{0, 1}, []uint{0, 1}, []float64{0, 0}, []float64{10, 10.5})
+	power = New(power.platform, power.application,
+		WithIdlePower(func(uint, float64) float64 { return 2 }))
+
+	P, ΔT = power.PartitionAveraged(schedule, ε)
+	assert.Equal(ΔT, []float64{10.5}, t)
+	assert.Close(P, []float64{(10 + 0.5*2) / 10.5, 100}, 1e-15, t)
+	assert.Close(P[0]*ΔT[0]+P[1]*ΔT[0], power.Energy(schedule), 1e-12, t)
+}
+
+func TestPartitionTotalOnly(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+	nc := schedule.Cores
+
+	P, ΔT := power.Partition(schedule, ε)
+	ns := uint(len(ΔT))
+
+	totals := make([]float64, ns)
+	for i := uint(0); i < ns; i++ {
+		for j := uint(0); j < nc; j++ {
+			totals[i] += P[i*nc+j]
+		}
+	}
+
+	result, ΔT := power.PartitionTotalOnly(schedule, ε)
+
+	assert.Close(result, totals, 1e-14, t)
+	assert.Close(ΔT, fixturePartition.ΔT, 1e-15, t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}),
+		WithIdlePower(func(j uint, time float64) float64 {
+			if time > schedule.Span/2 {
+				return 0.1
+			}
+			return float64(j + 1)
+		}))
+
+	P, ΔT = power.Partition(schedule, ε)
+	ns = uint(len(ΔT))
+
+	totals = make([]float64, ns)
+	for i := uint(0); i < ns; i++ {
+		for j := uint(0); j < nc; j++ {
+			totals[i] += P[i*nc+j]
+		}
+	}
+
+	result, _ = power.PartitionTotalOnly(schedule, ε)
+	assert.Close(result, totals, 1e-12, t)
+}
+
 func TestProgress(t *testing.T) {
 	const (
 		Δt = 1e-3
@@ -40,6 +204,202 @@ func TestProgress(t *testing.T) {
 	assert.Equal(P, fixtureSample.P, t)
 }
 
+func TestProgressBoundary(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 3}}, []uint{0, 1},
+		[]uint{0, 0}, []float64{0, 1}, []float64{1, 2})
+
+	P := make([]float64, 1)
+
+	power.Progress(schedule)(1, P)
+	assert.Equal(P, []float64{2}, t)
+	power.Progress(schedule)(2, P)
+	assert.Equal(P, []float64{3}, t)
+
+	power.SetBoundary(HalfOpen)
+
+	power.Progress(schedule)(1, P)
+	assert.Equal(P, []float64{3}, t)
+	power.Progress(schedule)(2, P)
+	assert.Equal(P, []float64{0}, t)
+}
+
+func TestProgressChanged(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{0, 0}, []float64{1, 2})
+
+	compute := power.ProgressChanged(schedule)
+	P, changed := make([]float64, 2), make([]bool, 2)
+
+	compute(0.5, P, changed)
+	assert.Equal(P, []float64{2, 3}, t)
+	assert.Equal(changed, []bool{true, true}, t)
+
+	compute(0.75, P, changed)
+	assert.Equal(changed, []bool{false, false}, t)
+
+	compute(1.5, P, changed)
+	assert.Equal(P, []float64{0, 3}, t)
+	assert.Equal(changed, []bool{true, false}, t)
+}
+
+func TestPartitionInto(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+	nc, ns := schedule.Cores, uint(len(fixturePartition.ΔT))
+
+	P, ΔT := power.PartitionInto(schedule, ε, nil, nil)
+	assert.Equal(P, fixturePartition.P, t)
+	assert.Close(ΔT, fixturePartition.ΔT, 1e-15, t)
+
+	bufferP, bufferΔT := make([]float64, nc*ns+1), make([]float64, ns+1)
+	for i := range bufferP {
+		bufferP[i] = 42
+	}
+
+	P, ΔT = power.PartitionInto(schedule, ε, bufferP, bufferΔT)
+	assert.Equal(P, fixturePartition.P, t)
+	assert.Close(ΔT, fixturePartition.ΔT, 1e-15, t)
+	assert.Equal(&P[0] == &bufferP[0], true, t)
+	assert.Equal(&ΔT[0] == &bufferΔT[0], true, t)
+
+	P, ΔT = power.PartitionInto(schedule, ε, P, ΔT)
+	assert.Equal(P, fixturePartition.P, t)
+
+	fresh := testing.AllocsPerRun(10, func() {
+		power.Partition(schedule, ε)
+	})
+	reused := testing.AllocsPerRun(10, func() {
+		P, ΔT = power.PartitionInto(schedule, ε, P, ΔT)
+	})
+	assert.Equal(reused, fresh-2, t)
+}
+
+func TestSampleInto(t *testing.T) {
+	const (
+		Δt = 1e-3
+	)
+
+	power, schedule := prepare("002_040")
+	nc := schedule.Cores
+
+	for _, ns := range []uint{440, 500} {
+		P, count := power.Sample(schedule, Δt, ns)
+
+		out := make([]float64, nc*ns+1)
+		for i := range out {
+			out[i] = 42
+		}
+
+		assert.Equal(power.SampleInto(schedule, Δt, ns, out), count, t)
+		assert.Equal(out[:nc*ns], P, t)
+		assert.Equal(out[nc*ns], 42.0, t)
+	}
+}
+
+func TestSampleLog(t *testing.T) {
+	const (
+		t0 = 1e-4
+		ns = 50
+	)
+
+	power, schedule := prepare("002_040")
+	nc := schedule.Cores
+
+	P, times := power.SampleLog(schedule, t0, ns)
+	assert.Equal(len(times), ns, t)
+	assert.Equal(times[0], t0, t)
+	assert.Equal(times[ns-1], schedule.Span, t)
+
+	compute, row := power.Progress(schedule), make([]float64, nc)
+	for k := uint(0); k < ns; k++ {
+		if k > 0 {
+			assert.Equal(times[k] > times[k-1], true, t)
+		}
+		compute(times[k], row)
+		assert.Equal(P[k*nc:(k+1)*nc], row, t)
+	}
+}
+
+func TestActiveTasks(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}}, []uint{0, 0, 0},
+		[]uint{0, 0, 1}, []float64{0, 1, 0}, []float64{2, 3, 1})
+
+	compute := power.ActiveTasks(schedule)
+	out := make([][]uint, 2)
+
+	compute(1.5, out)
+	assert.Equal(out[0], []uint{0, 1}, t)
+	assert.Equal(len(out[1]), 0, t)
+
+	compute(0.5, out)
+	assert.Equal(out, [][]uint{{0}, {2}}, t)
+
+	power.SetBoundary(HalfOpen)
+
+	power.ActiveTasks(schedule)(1, out)
+	assert.Equal(out[0], []uint{0, 1}, t)
+	assert.Equal(len(out[1]), 0, t)
+}
+
+func TestProgressEMA(t *testing.T) {
+	const (
+		alpha = 0.25
+	)
+
+	power, schedule := prepareCustom([][]float64{{4}}, []uint{0},
+		[]uint{0}, []float64{0}, []float64{100})
+
+	compute, P := power.ProgressEMA(schedule, alpha), make([]float64, 1)
+	for k := 1; k <= 20; k++ {
+		compute(float64(k), P)
+		assert.Close(P[0], 4*(1-math.Pow(1-alpha, float64(k))), 1e-12, t)
+	}
+
+	power.ProgressEMA(schedule, 1)(1, P)
+	assert.Equal(P, []float64{4}, t)
+}
+
+func TestProgressMatrix(t *testing.T) {
+	power, schedule := prepare("002_040")
+	nc := schedule.Cores
+
+	times := []float64{0, 0.01, 0.1, 0.2, schedule.Span, 2 * schedule.Span}
+	nk := uint(len(times))
+
+	P := power.ProgressMatrix(schedule, times)
+	compute, row := power.Progress(schedule), make([]float64, nc)
+	for k := uint(0); k < nk; k++ {
+		compute(times[k], row)
+		assert.Equal(P[k*nc:(k+1)*nc], row, t)
+	}
+
+	Q := power.ProgressMatrixByCore(schedule, times)
+	for k := uint(0); k < nk; k++ {
+		for j := uint(0); j < nc; j++ {
+			assert.Equal(Q[j*nk+k], P[k*nc+j], t)
+		}
+	}
+}
+
+func TestPowerAtTaskBoundary(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 3}, {4, 5}},
+		[]uint{0, 1, 1}, []uint{0, 0, 1},
+		[]float64{0, 1, 0}, []float64{1, 2, 2})
+
+	assert.Equal(power.PowerAtTaskBoundary(schedule, 0, true), []float64{2, 5}, t)
+	assert.Equal(power.PowerAtTaskBoundary(schedule, 1, true), []float64{2, 5}, t)
+	assert.Equal(power.PowerAtTaskBoundary(schedule, 1, false), []float64{3, 5}, t)
+
+	power.SetBoundary(HalfOpen)
+
+	assert.Equal(power.PowerAtTaskBoundary(schedule, 1, true), []float64{3, 5}, t)
+	assert.Equal(power.PowerAtTaskBoundary(schedule, 0, false), []float64{3, 5}, t)
+	assert.Equal(power.PowerAtTaskBoundary(schedule, 1, false), []float64{0, 0}, t)
+}
+
 func TestSample(t *testing.T) {
 	const (
 		Δt = 1e-3
@@ -47,8 +407,18 @@ func TestSample(t *testing.T) {
 
 	power, schedule := prepare("002_040")
 
-	assert.Equal(power.Sample(schedule, Δt, 440), fixtureSample.P, t)
-	assert.Equal(power.Sample(schedule, Δt, 42), fixtureSample.P[:2*42], t)
+	P, count := power.Sample(schedule, Δt, 440)
+	assert.Equal(P, fixtureSample.P, t)
+	assert.Equal(count, uint(440), t)
+
+	P, count = power.Sample(schedule, Δt, 42)
+	assert.Equal(P, fixtureSample.P[:2*42], t)
+	assert.Equal(count, uint(42), t)
+
+	P, count = power.Sample(schedule, Δt, 500)
+	assert.Equal(P[:2*440], fixtureSample.P, t)
+	assert.Equal(P[2*440:], make([]float64, 2*60), t)
+	assert.Equal(count, uint(440), t)
 }
 
 func TestTraverse(t *testing.T) {
@@ -57,7 +427,7 @@ func TestTraverse(t *testing.T) {
 	)
 
 	test := func(points, Δ []float64, steps []uint) {
-		a, b := traverse(points, ε)
+		a, b := traverse(points, ε, 0)
 		assert.Equal(a, Δ, t)
 		assert.Equal(b, steps, t)
 	}
@@ -96,6 +466,51 @@ func BenchmarkSample(b *testing.B) {
 	}
 }
 
+func BenchmarkPartitionInto(b *testing.B) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareSorted(4, 100000)
+	P, ΔT := power.Partition(schedule, ε)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		P, ΔT = power.PartitionInto(schedule, ε, P, ΔT)
+	}
+}
+
+func BenchmarkPartitionUnsorted(b *testing.B) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareSorted(4, 100000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		power.Partition(schedule, ε)
+	}
+}
+
+func BenchmarkPartitionSorted(b *testing.B) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareSorted(4, 100000)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		power.PartitionSorted(schedule, ε)
+	}
+}
+
 func prepare(name string) (*Power, *time.Schedule) {
 	platform, application, _ := system.Load(findFixture(fmt.Sprintf("%s.tgff", name)))
 	power := New(platform, application)
@@ -105,6 +520,57 @@ func prepare(name string) (*Power, *time.Schedule) {
 	return power, schedule
 }
 
+func prepareCustom(table [][]float64, types []uint, mapping []uint,
+	start, finish []float64) (*Power, *time.Schedule) {
+
+	platform := &system.Platform{Cores: make([]system.Core, len(table))}
+	for i := range table {
+		platform.Cores[i] = system.Core{Power: table[i]}
+	}
+
+	application := &system.Application{Tasks: make([]system.Task, len(types))}
+	for i := range types {
+		application.Tasks[i] = system.Task{Type: types[i]}
+	}
+
+	span := 0.0
+	for _, f := range finish {
+		if f > span {
+			span = f
+		}
+	}
+
+	schedule := &time.Schedule{
+		Cores:   uint(len(table)),
+		Tasks:   uint(len(types)),
+		Mapping: mapping,
+		Start:   start,
+		Finish:  finish,
+		Span:    span,
+	}
+
+	return New(platform, application), schedule
+}
+
+func prepareSorted(nc, nt uint) (*Power, *time.Schedule) {
+	table := make([][]float64, nc)
+	for j := range table {
+		table[j] = []float64{float64(j + 1), float64(2 * (j + 1))}
+	}
+
+	types := make([]uint, nt)
+	mapping := make([]uint, nt)
+	start, finish := make([]float64, nt), make([]float64, nt)
+	for i := uint(0); i < nt; i++ {
+		types[i] = i % 2
+		mapping[i] = i % nc
+		start[i] = 0.5 * float64(i)
+		finish[i] = start[i] + 0.25*float64(1+i%3)
+	}
+
+	return prepareCustom(table, types, mapping, start, finish)
+}
+
 func findFixture(name string) string {
 	const (
 		fixturePath = "fixtures"
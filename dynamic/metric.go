@@ -0,0 +1,281 @@
+package dynamic
+
+import (
+	"math"
+	"sort"
+
+	"github.com/turing-complete/time"
+)
+
+// Makespan returns the time elapsed from the start of the earliest task to the
+// finish of the latest one.
+//
+// The span of a schedule is measured from time zero; therefore, the two
+// coincide when the schedule starts at zero, and the makespan is shorter by the
+// start of the earliest task otherwise.
+func (self *Power) Makespan(schedule *time.Schedule) float64 {
+	return makespan(schedule)
+}
+
+func makespan(schedule *time.Schedule) float64 {
+	if schedule.Tasks == 0 {
+		return 0
+	}
+	start, finish := math.Inf(1), math.Inf(-1)
+	for i := uint(0); i < schedule.Tasks; i++ {
+		start = math.Min(start, schedule.Start[i])
+		finish = math.Max(finish, schedule.Finish[i])
+	}
+	return finish - start
+}
+
+// Peak returns the maximal total power consumption of all cores over the span
+// of a schedule. The peak is computed exactly using the intervals between power
+// switches, and the time before the first and after the last task is accounted
+// for at the static and idle power consumption.
+func (self *Power) Peak(schedule *time.Schedule) float64 {
+	power, schedule := self.prune(schedule)
+	return self.peak(power, schedule)
+}
+
+func (self *Power) peak(power []float64, schedule *time.Schedule) float64 {
+	levels, _ := self.levels(power, schedule, 0)
+	if len(levels) == 0 {
+		return 0
+	}
+	peak := levels[0]
+	for _, level := range levels[1:] {
+		peak = math.Max(peak, level)
+	}
+	return peak
+}
+
+// PeakPerCore returns the maximal power consumption of each core and the
+// earliest time moment at which it is reached. The peak of a core is the one of
+// the tasks mapped onto it even if their power consumption is negative, and
+// cores without any tasks have zero peaks at time zero.
+func (self *Power) PeakPerCore(schedule *time.Schedule) ([]float64, []float64) {
+	return peakPerCore(self.split(self.Distribute(schedule), schedule))
+}
+
+func peakPerCore(power []float64, schedule *time.Schedule) ([]float64, []float64) {
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	peaks, times := make([]float64, nc), make([]float64, nc)
+	seen := make([]bool, nc)
+	for i := uint(0); i < nt; i++ {
+		j, p, s := schedule.Mapping[i], power[i], schedule.Start[i]
+		if !seen[j] || p > peaks[j] || p == peaks[j] && s < times[j] {
+			peaks[j], times[j], seen[j] = p, s, true
+		}
+	}
+
+	return peaks, times
+}
+
+// Utilization returns the fraction of the span that each core spends running
+// tasks whose power consumption is at or above the given threshold, which is
+// relative to the peak power of the core and should be in [0, 1].
+func (self *Power) Utilization(schedule *time.Schedule, threshold float64) []float64 {
+	power, schedule := self.split(self.Distribute(schedule), schedule)
+	peaks, _ := peakPerCore(power, schedule)
+
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	utilization := make([]float64, nc)
+	if schedule.Span <= 0 {
+		return utilization
+	}
+
+	for i := uint(0); i < nt; i++ {
+		j := schedule.Mapping[i]
+		if power[i] >= threshold*math.Max(peaks[j], 0) {
+			utilization[j] += schedule.Finish[i] - schedule.Start[i]
+		}
+	}
+	for j := range utilization {
+		utilization[j] /= schedule.Span
+	}
+
+	return utilization
+}
+
+// TotalPowerSquaredIntegral returns the integral over time of the squared total
+// power consumption of all cores. The integral is computed exactly using the
+// intervals between power switches, and the time before the first and after
+// the last task is accounted for at the static and idle power consumption.
+func (self *Power) TotalPowerSquaredIntegral(schedule *time.Schedule) float64 {
+	power, schedule := self.prune(schedule)
+	levels, durations := self.levels(power, schedule, 0)
+
+	integral := 0.0
+	for i := range durations {
+		integral += levels[i] * levels[i] * durations[i]
+	}
+
+	return integral
+}
+
+// PowerVariance returns the time-weighted variance of the power consumption of
+// each core over the span of a schedule. The variance is computed exactly using
+// the intervals between power switches, and the time before the first and after
+// the last task is accounted for at the static and idle power of the core,
+// which are zero by default.
+func (self *Power) PowerVariance(schedule *time.Schedule) []float64 {
+	nc := schedule.Cores
+
+	variance := make([]float64, nc)
+	if schedule.Span <= 0 {
+		return variance
+	}
+
+	_, pruned := self.prune(schedule)
+	P, ΔT := self.Partition(schedule, 0)
+	start := origin(pruned)
+	finish := start + sum(ΔT)
+
+	for j := uint(0); j < nc; j++ {
+		mean, square := 0.0, 0.0
+		for i := range ΔT {
+			p := P[uint(i)*nc+j]
+			mean += p * ΔT[i]
+			square += p * p * ΔT[i]
+		}
+		if start > 0 {
+			p := self.resting(j, 0)
+			mean += p * start
+			square += p * p * start
+		}
+		if Δ := schedule.Span - finish; Δ > 0 {
+			p := self.resting(j, finish)
+			mean += p * Δ
+			square += p * p * Δ
+		}
+		mean /= schedule.Span
+		variance[j] = math.Max(square/schedule.Span-mean*mean, 0)
+	}
+
+	return variance
+}
+
+// Fairness returns Jain's fairness index of the energy consumption of the
+// cores, which is one when the energy is distributed evenly and 1/nc when it
+// is consumed by a single core. A schedule without any energy consumption is
+// considered fair.
+func (self *Power) Fairness(schedule *time.Schedule) float64 {
+	E := self.EnergyPerCore(schedule)
+
+	total, square := 0.0, 0.0
+	for _, e := range E {
+		total += e
+		square += e * e
+	}
+	if square == 0 {
+		return 1
+	}
+
+	return total * total / (float64(len(E)) * square)
+}
+
+// PercentilePower returns the total power consumption of all cores below which
+// the schedule spends fraction p of its span. The durations of the power levels
+// are computed exactly using the intervals between power switches, and the
+// time before the first and after the last task is accounted for at the static
+// and idle power consumption. Hence, p = 0 yields the minimal and p = 1 the
+// maximal total power consumption.
+func (self *Power) PercentilePower(schedule *time.Schedule, p float64) float64 {
+	power, pruned := self.prune(schedule)
+	levels, durations := self.levels(power, pruned, 0)
+
+	nl := len(levels)
+	if nl == 0 {
+		return 0
+	}
+
+	order := make([]int, nl)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return levels[order[i]] < levels[order[j]]
+	})
+
+	target, elapsed := p*schedule.Span, 0.0
+	for _, i := range order {
+		elapsed += durations[i]
+		if elapsed >= target {
+			return levels[i]
+		}
+	}
+
+	return levels[order[nl-1]]
+}
+
+// MaxTheoreticalPower returns the sum over the cores of the largest entry of
+// the power table of each core, or zero if all of them are negative, plus the
+// static power consumption of the core. The result bounds the peak power
+// consumption only when each core runs at most one task at a time and neither
+// idle power, operating points scaling the power up, activity factors above
+// one, nor custom power models are set, none of which is taken into account.
+func (self *Power) MaxTheoreticalPower() float64 {
+	total := 0.0
+	for j, core := range self.platform.Cores {
+		max := 0.0
+		for _, p := range core.Power {
+			max = math.Max(max, p)
+		}
+		total += max
+		if self.static != nil {
+			total += self.static[j]
+		}
+	}
+	return total
+}
+
+// ConcurrencyHistogram returns the time that the schedule spends with exactly k
+// cores running tasks for k from zero to the number of cores. The durations
+// are computed exactly using the start and finish times of the tasks, and
+// they sum up to the span of the schedule. As for OccupancyMask and
+// ActiveCount, a core is running a task only if the power consumption of the
+// task is nonzero after the power floor, if any, is applied.
+func (self *Power) ConcurrencyHistogram(schedule *time.Schedule) []float64 {
+	power, schedule := self.prune(schedule)
+
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	ΔT, ssteps, fsteps := divide(schedule, 0, 0)
+	ns := uint(len(ΔT))
+
+	events, offset := arrange(ssteps, fsteps, ns)
+
+	busy := make([]int, nc)
+	histogram, active := make([]float64, nc+1), 0
+	for k, l := uint(0), uint(0); k < ns; k++ {
+		m := offset[k]
+		for _, e := range events[l:m] {
+			i := e % nt
+			if power[i] == 0 {
+				continue
+			}
+			j := schedule.Mapping[i]
+			if e < nt {
+				if busy[j] == 0 {
+					active++
+				}
+				busy[j]++
+			} else {
+				busy[j]--
+				if busy[j] == 0 {
+					active--
+				}
+			}
+		}
+		l = m
+		histogram[active] += ΔT[k]
+	}
+	if idle := schedule.Span - sum(ΔT); idle > 0 {
+		histogram[0] += idle
+	}
+
+	return histogram
+}
@@ -0,0 +1,196 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestMakespan(t *testing.T) {
+	power, schedule := prepare("002_040")
+	assert.Equal(power.Makespan(schedule), schedule.Span, t)
+
+	power, schedule = prepareCustom([][]float64{{1}, {1}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{2, 3}, []float64{5, 7})
+	assert.Equal(schedule.Span, 7.0, t)
+	assert.Equal(power.Makespan(schedule), 5.0, t)
+}
+
+func TestPeak(t *testing.T) {
+	power, schedule := prepare("002_040")
+	assert.Equal(power.Peak(schedule), 15.01+11.21, t)
+
+	power, schedule = prepareCustom([][]float64{{2}, {3}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{0, 2}, []float64{2, 4})
+	assert.Equal(power.Peak(schedule), 3.0, t)
+
+	power, schedule = prepareCustom([][]float64{{0}}, nil, nil, nil, nil)
+	assert.Equal(power.Peak(schedule), 0.0, t)
+}
+
+func TestPeakIdle(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 0, 1},
+		[]float64{0, 3, 0}, []float64{1, 4, 4})
+	schedule.Span = 5
+
+	power = New(power.platform, power.application, WithConstantIdlePower([]float64{0.5, 4}))
+
+	assert.Equal(power.Peak(schedule), 5.0, t)
+	assert.Equal(power.PercentilePower(schedule, 0), 3.5, t)
+
+	totals, ΔT := power.PartitionTotalOnly(schedule, 0)
+	assert.Equal(totals, []float64{5, 3.5, 5}, t)
+	assert.Equal(ΔT, []float64{1, 2, 1}, t)
+
+	power = New(power.platform, power.application, WithConstantIdlePower([]float64{2, 4}))
+	assert.Equal(power.Peak(schedule), 6.0, t)
+}
+
+func TestPeakPerCore(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 5}, {3, 1}, {1, 1}},
+		[]uint{0, 1, 1, 0, 1, 0}, []uint{0, 0, 0, 1, 1, 1},
+		[]float64{0, 1, 4, 0, 2, 3}, []float64{1, 2, 5, 2, 3, 4})
+
+	peaks, times := power.PeakPerCore(schedule)
+	assert.Equal(peaks, []float64{5, 3, 0}, t)
+	assert.Equal(times, []float64{1, 0, 0}, t)
+
+	power, schedule = prepareCustom([][]float64{{-2, -1}, {1, 1}},
+		[]uint{0, 1, 0}, []uint{0, 0, 0},
+		[]float64{0, 1, 2}, []float64{1, 2, 3})
+
+	peaks, times = power.PeakPerCore(schedule)
+	assert.Equal(peaks, []float64{-1, 0}, t)
+	assert.Equal(times, []float64{1, 0}, t)
+}
+
+func TestUtilization(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 8}, {1, 1}},
+		[]uint{0, 1, 0}, []uint{0, 0, 1},
+		[]float64{0, 1, 0}, []float64{1, 4, 2})
+
+	assert.Close(power.Utilization(schedule, 0.5), []float64{0.75, 0.5}, 1e-15, t)
+	assert.Close(power.Utilization(schedule, 0), []float64{1, 0.5}, 1e-15, t)
+}
+
+func TestTotalPowerSquaredIntegral(t *testing.T) {
+	const (
+		Δt = 1e-6
+	)
+
+	power, schedule := prepare("002_040")
+	nc, ns := schedule.Cores, uint(schedule.Span/Δt)+1
+
+	P, _ := power.Sample(schedule, Δt, ns)
+	integral := 0.0
+	for i := uint(0); i < ns; i++ {
+		total := 0.0
+		for j := uint(0); j < nc; j++ {
+			total += P[i*nc+j]
+		}
+		integral += total * total * Δt
+	}
+
+	assert.Close(power.TotalPowerSquaredIntegral(schedule), integral, 1e-3, t)
+
+	power, schedule = prepareCustom([][]float64{{2}, {3}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{0, 1}, []float64{2, 4})
+
+	assert.Close(power.TotalPowerSquaredIntegral(schedule), 4.0+25.0+18.0, 1e-14, t)
+
+	power, schedule = prepareCustom([][]float64{{0}}, nil, nil, nil, nil)
+	assert.Equal(power.TotalPowerSquaredIntegral(schedule), 0.0, t)
+
+	power, schedule = prepareCustom([][]float64{{2}, {3}}, []uint{0},
+		[]uint{0}, []float64{1}, []float64{2})
+	schedule.Span = 3
+
+	static := New(power.platform, power.application, WithStaticPower([]float64{1, 1}))
+	assert.Equal(static.TotalPowerSquaredIntegral(schedule), 4.0+16.0+4.0, t)
+
+	idle := New(power.platform, power.application, WithConstantIdlePower([]float64{1, 1}))
+	assert.Equal(idle.TotalPowerSquaredIntegral(schedule), 4.0+9.0+4.0, t)
+}
+
+func TestPowerVariance(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0}, []uint{0, 1},
+		[]float64{1, 1}, []float64{2, 2})
+
+	assert.Equal(power.PowerVariance(schedule), []float64{1, 2.25}, t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 1}))
+	assert.Equal(power.PowerVariance(schedule), []float64{1, 2.25}, t)
+
+	power, schedule = prepareCustom([][]float64{{2}},
+		[]uint{0}, []uint{0},
+		[]float64{0}, []float64{2})
+
+	assert.Equal(power.PowerVariance(schedule), []float64{0}, t)
+}
+
+func TestFairness(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {2}, {2}, {2}},
+		[]uint{0, 0, 0, 0}, []uint{0, 1, 2, 3},
+		[]float64{0, 0, 1, 1}, []float64{1, 1, 2, 2})
+
+	assert.Equal(power.Fairness(schedule), 1.0, t)
+
+	power, schedule = prepareCustom([][]float64{{2}, {2}, {2}, {2}},
+		[]uint{0}, []uint{2}, []float64{0}, []float64{1})
+
+	assert.Equal(power.Fairness(schedule), 0.25, t)
+
+	power, schedule = prepareCustom([][]float64{{0}, {0}},
+		[]uint{0}, []uint{0}, []float64{0}, []float64{1})
+
+	assert.Equal(power.Fairness(schedule), 1.0, t)
+}
+
+func TestPercentilePower(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 4}},
+		[]uint{0, 1}, []uint{0, 0},
+		[]float64{1, 4}, []float64{4, 5})
+
+	assert.Equal(power.PercentilePower(schedule, 0), 0.0, t)
+	assert.Equal(power.PercentilePower(schedule, 0.2), 0.0, t)
+	assert.Equal(power.PercentilePower(schedule, 0.5), 2.0, t)
+	assert.Equal(power.PercentilePower(schedule, 0.8), 2.0, t)
+	assert.Equal(power.PercentilePower(schedule, 0.95), 4.0, t)
+	assert.Equal(power.PercentilePower(schedule, 1), power.Peak(schedule), t)
+}
+
+func TestMaxTheoreticalPower(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 8, 3}, {1, -1, 4}, {-2, -3, -1}},
+		[]uint{0, 1, 2}, []uint{0, 1, 2},
+		[]float64{0, 0, 0}, []float64{1, 1, 1})
+
+	assert.Equal(power.MaxTheoreticalPower(), 12.0, t)
+	assert.Equal(power.Peak(schedule) <= power.MaxTheoreticalPower(), true, t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 1, 1}))
+	assert.Equal(power.MaxTheoreticalPower(), 15.0, t)
+}
+
+func TestConcurrencyHistogram(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}, {4}},
+		[]uint{0, 0, 0, 0}, []uint{0, 1, 2, 0},
+		[]float64{1, 2, 3, 6}, []float64{4, 5, 4, 7})
+
+	histogram := power.ConcurrencyHistogram(schedule)
+	assert.Equal(histogram, []float64{2, 3, 1, 1}, t)
+	assert.Equal(sum(histogram), schedule.Span, t)
+
+	power, schedule = prepareCustom([][]float64{{2, 0}, {3, 0.5}},
+		[]uint{0, 1, 1}, []uint{0, 1, 0},
+		[]float64{0, 0, 2}, []float64{2, 3, 3})
+
+	histogram = power.ConcurrencyHistogram(schedule)
+	assert.Equal(histogram, []float64{0, 1, 2}, t)
+
+	power = New(power.platform, power.application, WithPowerFloor(1))
+	histogram = power.ConcurrencyHistogram(schedule)
+	assert.Equal(histogram, []float64{1, 2, 0}, t)
+	assert.Equal(power.ActiveCount(schedule, 1, 3), []uint{1, 1, 0}, t)
+}
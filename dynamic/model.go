@@ -0,0 +1,22 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/system"
+)
+
+// Model is a model of the power consumption of tasks.
+type Model interface {
+	// Power returns the power consumption of a task on a core.
+	Power(core, task uint) float64
+}
+
+// table is the default model, which looks up the power consumption in the
+// tables of the cores indexed by the types of the tasks.
+type table struct {
+	platform    *system.Platform
+	application *system.Application
+}
+
+func (self *table) Power(core, task uint) float64 {
+	return self.platform.Cores[core].Power[self.application.Tasks[task].Type]
+}
@@ -0,0 +1,25 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+type sumModel struct{}
+
+func (sumModel) Power(core, task uint) float64 {
+	return float64(core + task)
+}
+
+func TestWithModel(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{1, 2}, {3, 4}},
+		[]uint{0, 1, 0, 1}, []uint{0, 1, 1, 0},
+		[]float64{0, 0, 1, 1}, []float64{1, 1, 2, 2})
+
+	assert.Equal(power.Distribute(schedule), PowerVector{1, 4, 3, 2}, t)
+
+	power = New(power.platform, power.application, WithModel(sumModel{}))
+
+	assert.Equal(power.Distribute(schedule), PowerVector{0, 2, 3, 3}, t)
+}
@@ -0,0 +1,35 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestNegativePower(t *testing.T) {
+	const (
+		ε  = 1e-14
+		Δt = 0.5
+	)
+
+	power, schedule := prepareCustom([][]float64{{2, -3}, {-1, 4}},
+		[]uint{0, 1, 1, 0}, []uint{0, 0, 1, 1},
+		[]float64{0, 1, 0, 2}, []float64{1, 2, 2, 3})
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{2, 4, -3, 4, 0, -1}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	P, _ = power.Sample(schedule, Δt, 6)
+	assert.Equal(P, []float64{2, 4, 2, 4, -3, 4, -3, 4, 0, -1, 0, -1}, t)
+
+	assert.Equal(power.EnergyPerCore(schedule), []float64{-1, 7}, t)
+	assert.Equal(power.Energy(schedule), 6.0, t)
+	assert.Equal(power.Peak(schedule), 6.0, t)
+
+	power, schedule = prepareCustom([][]float64{{-2}, {-3}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{0, 0}, []float64{2, 2})
+
+	assert.Equal(power.Energy(schedule), -10.0, t)
+	assert.Equal(power.Peak(schedule), -5.0, t)
+}
@@ -0,0 +1,60 @@
+package dynamic
+
+import (
+	"math/bits"
+
+	"github.com/turing-complete/time"
+)
+
+// OccupancyMask computes which cores are active at each sample with respect to
+// a sampling interval Δt. A core is active when it runs a task with a nonzero
+// dynamic power consumption. The activity at each sample is encoded by
+// ⌈nc/64⌉ consecutive words, and core j is represented by bit j%64 of word
+// j/64. The samples are the same as the ones of Sample.
+func (self *Power) OccupancyMask(schedule *time.Schedule, Δt float64, ns uint) []uint64 {
+	nc, nt := schedule.Cores, schedule.Tasks
+	nw := (nc + 63) / 64
+
+	mask := make([]uint64, nw*ns)
+
+	if count := uint(schedule.Span / Δt); count < ns {
+		ns = count
+	}
+
+	power, schedule := self.prune(schedule)
+	nt = schedule.Tasks
+
+	for i := uint(0); i < nt; i++ {
+		if power[i] == 0 {
+			continue
+		}
+
+		j := schedule.Mapping[i]
+		w, b := j/64, uint64(1)<<(j%64)
+
+		s, f := grid(schedule, i, Δt, ns)
+
+		for ; s < f; s++ {
+			mask[s*nw+w] |= b
+		}
+	}
+
+	return mask
+}
+
+// ActiveCount computes the number of active cores at each sample with respect
+// to a sampling interval Δt. The activity is defined as in OccupancyMask.
+func (self *Power) ActiveCount(schedule *time.Schedule, Δt float64, ns uint) []uint {
+	nw := (schedule.Cores + 63) / 64
+
+	mask := self.OccupancyMask(schedule, Δt, ns)
+
+	count := make([]uint, ns)
+	for i := uint(0); i < ns; i++ {
+		for _, word := range mask[i*nw : (i+1)*nw] {
+			count[i] += uint(bits.OnesCount64(word))
+		}
+	}
+
+	return count
+}
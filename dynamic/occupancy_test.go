@@ -0,0 +1,47 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestOccupancyMask(t *testing.T) {
+	const (
+		Δt = 1e-3
+	)
+
+	power, schedule := prepare("002_040")
+	nc, ns := schedule.Cores, uint(440)
+
+	P, _ := power.Sample(schedule, Δt, ns)
+	mask := power.OccupancyMask(schedule, Δt, ns)
+	assert.Equal(uint(len(mask)), ns, t)
+
+	for i := uint(0); i < ns; i++ {
+		for j := uint(0); j < nc; j++ {
+			assert.Equal(mask[i]>>j&1 == 1, P[i*nc+j] != 0, t)
+		}
+	}
+
+	power, schedule = prepareRandom(70, 200)
+	nc, ns = schedule.Cores, uint(schedule.Span/Δt)
+
+	P, _ = power.Sample(schedule, Δt, ns)
+	mask = power.OccupancyMask(schedule, Δt, ns)
+	assert.Equal(uint(len(mask)), 2*ns, t)
+
+	for i := uint(0); i < ns; i++ {
+		for j := uint(0); j < nc; j++ {
+			assert.Equal(mask[2*i+j/64]>>(j%64)&1 == 1, P[i*nc+j] != 0, t)
+		}
+	}
+}
+
+func TestActiveCount(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{1}, {2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 1, 2},
+		[]float64{0, 1, 2}, []float64{3, 4, 5})
+
+	assert.Equal(power.ActiveCount(schedule, 1, 6), []uint{1, 2, 3, 2, 1, 0}, t)
+}
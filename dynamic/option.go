@@ -0,0 +1,154 @@
+package dynamic
+
+// Option is a configuration option of a power calculator.
+type Option func(*Power)
+
+// WithActivity sets the activity factor of each task, which scales the power
+// consumption given by the model and reflects the switching activity of the
+// particular task instance. By default, the factor is one for all tasks.
+func WithActivity(activity []float64) Option {
+	return func(power *Power) {
+		power.activity = activity
+	}
+}
+
+// WithDVFS sets the operating point of each task under dynamic voltage and
+// frequency scaling. The frequency and voltage of task i are given relative to
+// the nominal ones, which the power consumption given by the model corresponds
+// to, and the power consumption is scaled by frequency[i]·voltage[i]². The
+// start and finish times of the schedule are taken as is; the effect of the
+// frequency on the duration of the tasks should be accounted for by the
+// scheduler. The scaling composes with the activity factor.
+func WithDVFS(frequency, voltage []float64) Option {
+	return func(power *Power) {
+		power.scaling = make([]float64, len(frequency))
+		for i := range frequency {
+			power.scaling[i] = frequency[i] * voltage[i] * voltage[i]
+		}
+	}
+}
+
+// WithPowerFloor sets a threshold below which the power consumption of a task
+// is treated as zero. Such tasks are then considered idle, and they do not
+// give rise to additional time steps in Partition. Note that the energy
+// consumption changes accordingly.
+func WithPowerFloor(floor float64) Option {
+	return func(power *Power) {
+		power.floor = floor
+	}
+}
+
+// WithRelativeTolerance sets a relative tolerance for merging the time moments
+// of power switches into time steps. Two moments a and b are merged if they
+// are within the absolute tolerance ε passed to Partition and its relatives or
+// if |a - b| <= rtol·max(|a|, |b|), which keeps the merging robust for
+// schedules with large time values. By default, only ε is used.
+func WithRelativeTolerance(rtol float64) Option {
+	return func(power *Power) {
+		power.rtol = rtol
+	}
+}
+
+// WithStaticPower sets the static power consumption of each core, which is
+// drawn constantly over the span of a schedule on top of the dynamic one.
+func WithStaticPower(static []float64) Option {
+	return func(power *Power) {
+		power.static = static
+	}
+}
+
+// WithModel sets the model of the power consumption of tasks. By default, the
+// power consumption is looked up in the tables of the cores of the platform.
+func WithModel(model Model) Option {
+	return func(power *Power) {
+		power.model = model
+	}
+}
+
+// WithDomainLeakage sets the leakage power of voltage islands. Each core is
+// assigned to a domain via domains, and each domain draws the corresponding
+// leakage power on every one of its cores. The leakage power is added to the
+// static power of the cores and is treated the same way.
+func WithDomainLeakage(domains []uint, leakage []float64) Option {
+	return func(power *Power) {
+		power.domains = domains
+		power.leakage = leakage
+	}
+}
+
+// WithIdlePower sets the power consumption of the cores while they are not
+// running any tasks, which is given as a function of the core and time and can
+// model sleep states entered after a period of inactivity. The idle power is
+// summed with the static power; while a core is running a task, the dynamic
+// power of the task replaces the idle one.
+//
+// The idle power is taken into account by the profiles of Partition and its
+// variants, including PartitionAveraged, PartitionFunc, PartitionTotalOnly,
+// RunLength, Sample and its variants, SampleFunc, SampleReduce,
+// SamplePeakStream, SampleSparse, SamplePeriodic, Summary, and Progress; by
+// Peak, PercentilePower, and PowerVariance; and by the energy of Energy,
+// EnergyPerCore, EnergyBreakdown, EnergyWindow, EnergySplit,
+// CumulativeEnergyPerCore, and PartitionFuncEnergy. It is not attributed to
+// tasks, so the per-task and per-type energies exclude it, and it is ignored by
+// EachEvent and SampleTicks. Partition evaluates the function at the start of
+// each time step, Sample at each sample, Progress at the queried time moment
+// within the span of the schedule, and the energy computations at the time
+// moments of Partition with ε equal to zero, splitting the idle intervals of
+// the cores at those moments; hence, the energy agrees with the integral of
+// Partition when no moments are merged and with the one of Sample up to the
+// sampling error.
+func WithIdlePower(idle func(uint, float64) float64) Option {
+	return func(power *Power) {
+		power.idle = idle
+	}
+}
+
+// WithConstantIdlePower is the same as WithIdlePower except that the idle power
+// consumption of each core is constant.
+func WithConstantIdlePower(idle []float64) Option {
+	return WithIdlePower(func(j uint, _ float64) float64 {
+		return idle[j]
+	})
+}
+
+// WithPowerVariance sets the variance of the power consumption of each task,
+// which makes the power consumption uncertain. The power consumption of task i
+// is then a Gaussian random variable whose mean is the power consumption
+// computed otherwise and whose variance is variance[i], which is absolute;
+// DistributeRandom draws from the same distribution but accepts the standard
+// deviation relative to the mean instead. The variance is taken into account
+// only by the computations that draw realizations, such as SampleRandom, and
+// by Variance.
+func WithPowerVariance(variance []float64) Option {
+	return func(power *Power) {
+		power.variance = variance
+	}
+}
+
+// WithPowerSampler sets a sampler that draws realizations of the power
+// consumption of tasks, which makes the power consumption uncertain with an
+// arbitrary distribution. The sampler takes precedence over WithPowerVariance
+// when realizations are drawn.
+func WithPowerSampler(sampler Sampler) Option {
+	return func(power *Power) {
+		power.sampler = sampler
+	}
+}
+
+// WithPhases sets the phases of the execution of each task, which allows the
+// power consumption of a task to vary over its execution. The phases of task i
+// follow one another in the given order, each taking the given fraction of the
+// duration of the task and drawing the given fraction of the power consumption
+// computed for the task otherwise. The fractions of the duration should sum to
+// one; the last phase always ends at the finish time of the task. A task
+// without phases draws a constant power, and a ramp can be approximated by a
+// sequence of short phases. All the computations honor the phases except for
+// SampleTicks, whose schedules are given in integer clock ticks, and the ones
+// that report the power consumption of whole tasks, such as Distribute; in
+// particular, the energy of a task is its power consumption times its duration
+// weighted by the sum of Fraction·Power over its phases.
+func WithPhases(phases [][]Phase) Option {
+	return func(power *Power) {
+		power.phases = phases
+	}
+}
@@ -0,0 +1,212 @@
+package dynamic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestWithActivity(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 4}, {3, 6}},
+		[]uint{0, 1, 0}, []uint{0, 0, 1},
+		[]float64{0, 1, 0}, []float64{1, 2, 3})
+
+	assert.Equal(power.Distribute(schedule), PowerVector{2, 4, 3}, t)
+	E := power.Energy(schedule)
+
+	power = New(power.platform, power.application, WithActivity([]float64{0.5, 1, 0.5}))
+
+	assert.Equal(power.Distribute(schedule), PowerVector{1, 4, 1.5}, t)
+	assert.Equal(power.Energy(schedule), E-1-4.5, t)
+}
+
+func TestWithDVFS(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 4}, {3, 6}},
+		[]uint{0, 1, 0}, []uint{0, 0, 1},
+		[]float64{0, 1, 0}, []float64{1, 2, 3})
+
+	power = New(power.platform, power.application,
+		WithDVFS([]float64{0.5, 1, 2}, []float64{1, 0.5, 1}))
+
+	assert.Equal(power.Distribute(schedule), PowerVector{1, 1, 6}, t)
+	assert.Success(power.Validate(schedule), t)
+
+	power = New(power.platform, power.application,
+		WithDVFS([]float64{0.5, 1, 2}, []float64{1, 0.5, 1}),
+		WithActivity([]float64{0.5, 0.5, 0.5}))
+
+	assert.Equal(power.Distribute(schedule), PowerVector{0.5, 0.5, 3}, t)
+
+	power = New(power.platform, power.application, WithDVFS([]float64{1}, []float64{1}))
+	assert.Failure(power.Validate(schedule), t)
+}
+
+func TestWithPowerFloor(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2, 1e-6}, {3, 1e-6}},
+		[]uint{0, 1, 0}, []uint{0, 0, 1},
+		[]float64{0, 1, 0}, []float64{1, 2, 3})
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{2, 3, 1e-6, 3, 0, 3}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	power = New(power.platform, power.application, WithPowerFloor(1e-3))
+
+	P, ΔT = power.Partition(schedule, ε)
+	assert.Equal(P, []float64{2, 3, 0, 3}, t)
+	assert.Equal(ΔT, []float64{1, 2}, t)
+
+	E := window(power.Distribute(schedule), schedule, math.Inf(-1), math.Inf(1))
+	assert.Equal(E, []float64{2, 9}, t)
+}
+
+func TestWithStaticPower(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0}, []uint{0, 1},
+		[]float64{0, 1}, []float64{1, 2})
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{2.5, 0.25, 0.5, 3.25}, t)
+	assert.Equal(ΔT, []float64{1, 1}, t)
+
+	totals, _ := power.PartitionTotalOnly(schedule, ε)
+	assert.Equal(totals, []float64{2.75, 3.75}, t)
+
+	P, _ = power.Sample(schedule, 0.5, 5)
+	assert.Equal(P, []float64{
+		2.5, 0.25, 2.5, 0.25, 0.5, 3.25, 0.5, 3.25, 0, 0,
+	}, t)
+
+	result := make([]float64, 2)
+	power.Progress(schedule)(1.5, result)
+	assert.Equal(result, []float64{0.5, 3.25}, t)
+	power.Progress(schedule)(3, result)
+	assert.Equal(result, []float64{0, 0}, t)
+
+	P, last := []float64(nil), 0.0
+	current := make([]float64, 2)
+	power.EachEvent(schedule, ε, func(time float64, j uint, Δ float64) {
+		if time != last {
+			P, last = append(P, current...), time
+		}
+		current[j] += Δ
+	})
+	assert.Equal(P, []float64{2.5, 0.25, 0.5, 3.25}, t)
+	assert.Equal(current, []float64{0, 0}, t)
+}
+
+func TestWithRelativeTolerance(t *testing.T) {
+	const (
+		ε = 1e-9
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0}, []uint{0, 1},
+		[]float64{1e9, 1e9 + 1e-5}, []float64{1e9 + 1, 1e9 + 1})
+
+	_, ΔT := power.Partition(schedule, ε)
+	assert.Equal(len(ΔT), 2, t)
+
+	power = New(power.platform, power.application, WithRelativeTolerance(1e-12))
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{2, 3}, t)
+	assert.Equal(ΔT, []float64{1}, t)
+	assert.Equal(power.PartitionSteps(schedule, ε), uint(1), t)
+
+	P, ΔT = power.PartitionSorted(schedule, ε)
+	assert.Equal(P, []float64{2, 3}, t)
+	assert.Equal(ΔT, []float64{1}, t)
+}
+
+func TestWithIdlePower(t *testing.T) {
+	const (
+		ε  = 1e-14
+		Δt = 1
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 0, 1},
+		[]float64{0, 3, 0}, []float64{1, 4, 4})
+
+	idle := func(j uint, time float64) float64 {
+		if time < 2 {
+			return 0.5
+		}
+		return 0.125
+	}
+
+	power = New(power.platform, power.application, WithIdlePower(idle),
+		WithStaticPower([]float64{1, 1}))
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{3, 4, 1.5, 4, 3, 4}, t)
+	assert.Equal(ΔT, []float64{1, 2, 1}, t)
+
+	P, ns := power.Sample(schedule, Δt, 5)
+	assert.Equal(ns, uint(4), t)
+	assert.Equal(P, []float64{3, 4, 1.5, 4, 1.125, 4, 3, 4, 0, 0}, t)
+}
+
+func TestWithDomainLeakage(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {2}, {2}, {2}},
+		[]uint{0, 0}, []uint{0, 2},
+		[]float64{0, 1}, []float64{1, 2})
+
+	power = New(power.platform, power.application,
+		WithDomainLeakage([]uint{0, 0, 1, 1}, []float64{0.5, 0.25}))
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{2.5, 0.5, 0.25, 0.25, 0.5, 0.5, 2.25, 0.25}, t)
+	assert.Equal(ΔT, []float64{1, 1}, t)
+
+	_, static := power.EnergyBreakdown(schedule)
+	assert.Equal(static, []float64{1, 1, 0.5, 0.5}, t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 1, 1, 1}),
+		WithDomainLeakage([]uint{0, 0, 1, 1}, []float64{0.5, 0.25}))
+
+	_, static = power.EnergyBreakdown(schedule)
+	assert.Equal(static, []float64{3, 3, 2.5, 2.5}, t)
+	assert.Success(power.Validate(schedule), t)
+}
+
+func TestWithConstantIdlePower(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 0, 1},
+		[]float64{0, 3, 0}, []float64{1, 4, 4})
+
+	power = New(power.platform, power.application, WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{2, 3, 0.5, 3, 2, 3}, t)
+	assert.Equal(ΔT, []float64{1, 2, 1}, t)
+
+	P, _ = power.Sample(schedule, 1, 4)
+	assert.Equal(P, []float64{2, 3, 0.5, 3, 0.5, 3, 2, 3}, t)
+
+	compute, result := power.Progress(schedule), make([]float64, 2)
+	compute(2, result)
+	assert.Equal(result, []float64{0.5, 3}, t)
+	compute(5, result)
+	assert.Equal(result, []float64{0, 0}, t)
+}
@@ -0,0 +1,119 @@
+package dynamic
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/turing-complete/time"
+)
+
+// SetWorkers sets the number of goroutines used for filling power profiles in
+// Partition, Sample, and their relatives. The work is split by core, and a
+// value less than two disables parallelism.
+//
+// The goroutines are started on the first parallel computation and are reused
+// by the subsequent ones until the number of workers is changed or Close is
+// called. If neither happens, they are released once the power calculator is
+// garbage collected. A power calculator should be used by one goroutine at a
+// time; for concurrent computations, each goroutine should have its own copy
+// obtained via Clone.
+func (self *Power) SetWorkers(workers uint) {
+	self.Close()
+	self.workers = workers
+}
+
+// Close releases the goroutines of parallel computations, if any. The power
+// calculator remains usable, and the goroutines are started anew by the next
+// parallel computation.
+func (self *Power) Close() {
+	if self.pool == nil {
+		return
+	}
+	runtime.SetFinalizer(self, nil)
+	self.pool.close()
+	self.pool = nil
+}
+
+// Clone returns a copy of the power calculator. The copy shares the platform,
+// application, and options with the original but has its own goroutines.
+func (self *Power) Clone() *Power {
+	clone := *self
+	clone.pool = nil
+	return &clone
+}
+
+// parallel returns the pool of goroutines for parallel computations, which is
+// nil if parallelism is disabled.
+func (self *Power) parallel() *pool {
+	if self.workers < 2 {
+		return nil
+	}
+	if self.pool == nil {
+		self.pool = newPool(self.workers)
+		runtime.SetFinalizer(self, (*Power).Close)
+	}
+	return self.pool
+}
+
+type pool struct {
+	size uint
+	jobs chan func()
+}
+
+func newPool(size uint) *pool {
+	pool := &pool{size: size, jobs: make(chan func())}
+	for k := uint(0); k < size; k++ {
+		go func() {
+			for job := range pool.jobs {
+				job()
+			}
+		}()
+	}
+	return pool
+}
+
+func (self *pool) close() {
+	close(self.jobs)
+}
+
+// dispatch invokes a job for each task of a schedule. When a pool is given,
+// the tasks are grouped by core, and the groups are processed concurrently;
+// therefore, jobs for tasks mapped onto different cores should not write to
+// the same memory.
+func dispatch(schedule *time.Schedule, pool *pool, job func(uint)) {
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	workers := uint(0)
+	if pool != nil {
+		workers = pool.size
+	}
+	if workers > nc {
+		workers = nc
+	}
+
+	if workers < 2 {
+		for i := uint(0); i < nt; i++ {
+			job(i)
+		}
+		return
+	}
+
+	groups := make([][]uint, workers)
+	for i := uint(0); i < nt; i++ {
+		k := schedule.Mapping[i] % workers
+		groups[k] = append(groups[k], i)
+	}
+
+	var group sync.WaitGroup
+	group.Add(int(workers))
+	for k := uint(0); k < workers; k++ {
+		tasks := groups[k]
+		pool.jobs <- func() {
+			defer group.Done()
+			for _, i := range tasks {
+				job(i)
+			}
+		}
+	}
+	group.Wait()
+}
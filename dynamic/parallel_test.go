@@ -0,0 +1,202 @@
+package dynamic
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/time"
+)
+
+func TestPartitionParallel(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareRandom(32, 1000)
+
+	P1, ΔT1 := power.Partition(schedule, ε)
+
+	power.SetWorkers(4)
+	P2, ΔT2 := power.Partition(schedule, ε)
+
+	assert.Equal(P2, P1, t)
+	assert.Equal(ΔT2, ΔT1, t)
+}
+
+func TestClone(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareRandom(32, 1000)
+	power.SetWorkers(4)
+
+	P, ΔT := power.Partition(schedule, ε)
+
+	clone := power.Clone()
+	assert.Equal(clone.pool == nil, true, t)
+
+	P1, ΔT1 := clone.Partition(schedule, ε)
+	assert.Equal(P1, P, t)
+	assert.Equal(ΔT1, ΔT, t)
+	assert.Equal(clone.pool != power.pool, true, t)
+
+	power.Close()
+	assert.Equal(power.pool == nil, true, t)
+	clone.Close()
+}
+
+func TestWorkersRelease(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	_, schedule := prepareRandom(32, 100)
+	before := settle()
+
+	power, _ := prepareRandom(32, 100)
+	power.SetWorkers(4)
+	power.Partition(schedule, ε)
+	pool := power.pool
+	power.Sample(schedule, 1e-2, 100)
+	assert.Equal(power.pool == pool, true, t)
+	assert.Equal(settle(), before+4, t)
+
+	power.Close()
+	power.Partition(schedule, ε)
+	power.SetWorkers(0)
+	assert.Equal(settle(), before, t)
+
+	for i := 0; i < 10; i++ {
+		power, _ := prepareRandom(32, 100)
+		power.SetWorkers(4)
+		power.Partition(schedule, ε)
+	}
+	assert.Equal(settle(), before, t)
+}
+
+// settle runs the garbage collector and yields repeatedly so that released
+// goroutines exit, and it returns the number of goroutines left.
+func settle() int {
+	for k := 0; k < 100; k++ {
+		runtime.GC()
+		runtime.Gosched()
+	}
+	return runtime.NumGoroutine()
+}
+
+func BenchmarkDispatchFresh(b *testing.B) {
+	_, schedule := prepareRandom(32, 2000)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pool := newPool(8)
+		dispatch(schedule, pool, func(uint) {})
+		pool.close()
+	}
+}
+
+func BenchmarkDispatchReused(b *testing.B) {
+	_, schedule := prepareRandom(32, 2000)
+	pool := newPool(8)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		dispatch(schedule, pool, func(uint) {})
+	}
+
+	b.StopTimer()
+	pool.close()
+}
+
+func TestSampleParallel(t *testing.T) {
+	const (
+		Δt = 1e-2
+		ns = 10000
+	)
+
+	power, schedule := prepareRandom(32, 1000)
+
+	P1, count1 := power.Sample(schedule, Δt, ns)
+
+	power.SetWorkers(4)
+	P2, count2 := power.Sample(schedule, Δt, ns)
+
+	assert.Equal(P2, P1, t)
+	assert.Equal(count2, count1, t)
+}
+
+func BenchmarkSampleSerial(b *testing.B) {
+	benchmarkSample(1, b)
+}
+
+func BenchmarkSampleParallel(b *testing.B) {
+	benchmarkSample(8, b)
+}
+
+func benchmarkSample(workers uint, b *testing.B) {
+	const (
+		Δt = 1e-3
+		ns = 100000
+	)
+
+	power, schedule := prepareRandom(32, 2000)
+	power.SetWorkers(workers)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		power.Sample(schedule, Δt, ns)
+	}
+}
+
+func BenchmarkPartitionSerial(b *testing.B) {
+	benchmarkPartition(1, b)
+}
+
+func BenchmarkPartitionParallel(b *testing.B) {
+	benchmarkPartition(8, b)
+}
+
+func benchmarkPartition(workers uint, b *testing.B) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareRandom(32, 2000)
+	power.SetWorkers(workers)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		power.Partition(schedule, ε)
+	}
+}
+
+func prepareRandom(nc, nt uint) (*Power, *time.Schedule) {
+	generator := rand.New(rand.NewSource(0))
+
+	table := make([][]float64, nc)
+	for j := range table {
+		table[j] = []float64{1 + generator.Float64(), 1 + generator.Float64()}
+	}
+
+	types := make([]uint, nt)
+	mapping := make([]uint, nt)
+	start, finish := make([]float64, nt), make([]float64, nt)
+	time := make([]float64, nc)
+	for i := uint(0); i < nt; i++ {
+		j := uint(generator.Intn(int(nc)))
+		types[i] = uint(generator.Intn(2))
+		mapping[i] = j
+		start[i] = time[j] + generator.Float64()
+		finish[i] = start[i] + generator.Float64()
+		time[j] = finish[i]
+	}
+
+	return prepareCustom(table, types, mapping, start, finish)
+}
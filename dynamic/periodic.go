@@ -0,0 +1,64 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+// SamplePeriodic is the same as Sample except that the schedule is repeated
+// the given number of times with the given period. The repeated schedule is
+// not constructed explicitly.
+func (self *Power) SamplePeriodic(schedule *time.Schedule, Δt, period float64,
+	repeats, ns uint) ([]float64, uint) {
+
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	P := make([]float64, nc*ns)
+	if repeats == 0 {
+		return P, 0
+	}
+
+	span := float64(repeats-1)*period + schedule.Span
+	if count := uint(span / Δt); count < ns {
+		ns = count
+	}
+
+	var busy []bool
+	if self.idle != nil {
+		busy = make([]bool, nc*ns)
+	}
+
+	power, schedule := self.prune(schedule)
+	nt = schedule.Tasks
+
+	for r := uint(0); r < repeats; r++ {
+		shift := float64(r) * period
+		for i := uint(0); i < nt; i++ {
+			j := schedule.Mapping[i]
+			p := power[i]
+
+			s := uint((schedule.Start[i]+shift)/Δt + 0.5)
+			f := uint((schedule.Finish[i]+shift)/Δt + 0.5)
+			if f > ns {
+				f = ns
+			}
+
+			for ; s < f; s++ {
+				P[s*nc+j] += p
+				if busy != nil {
+					busy[s*nc+j] = true
+				}
+			}
+		}
+	}
+
+	self.superimpose(P, nc, ns)
+	for k := uint(0); busy != nil && k < ns; k++ {
+		for j := uint(0); j < nc; j++ {
+			if !busy[k*nc+j] {
+				P[k*nc+j] += self.idle(j, float64(k)*Δt)
+			}
+		}
+	}
+
+	return P, ns
+}
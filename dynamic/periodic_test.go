@@ -0,0 +1,51 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/time"
+)
+
+func TestSamplePeriodic(t *testing.T) {
+	const (
+		Δt     = 1e-3
+		period = 0.5
+	)
+
+	power, schedule := prepare("002_040")
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	unrolled := &time.Schedule{
+		Cores:   nc,
+		Tasks:   2 * nt,
+		Mapping: append(append([]uint{}, schedule.Mapping...), schedule.Mapping...),
+		Start:   make([]float64, 2*nt),
+		Finish:  make([]float64, 2*nt),
+		Span:    period + schedule.Span,
+	}
+	for i := uint(0); i < nt; i++ {
+		unrolled.Start[i], unrolled.Finish[i] = schedule.Start[i], schedule.Finish[i]
+		unrolled.Start[nt+i] = schedule.Start[i] + period
+		unrolled.Finish[nt+i] = schedule.Finish[i] + period
+	}
+
+	application := *power.application
+	application.Tasks = append(application.Tasks[:nt:nt], application.Tasks...)
+	other := New(power.platform, &application)
+
+	for _, ns := range []uint{1000, 800} {
+		P1, count1 := power.SamplePeriodic(schedule, Δt, period, 2, ns)
+		P2, count2 := other.Sample(unrolled, Δt, ns)
+		assert.Equal(P1, P2, t)
+		assert.Equal(count1, count2, t)
+	}
+
+	idle := WithConstantIdlePower([]float64{0.5, 0.25})
+	power = New(power.platform, power.application, idle)
+	other = New(power.platform, &application, idle)
+
+	P1, _ := power.SamplePeriodic(schedule, Δt, period, 2, 1000)
+	P2, _ := other.Sample(unrolled, Δt, 1000)
+	assert.Equal(P1, P2, t)
+}
@@ -0,0 +1,70 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+// Phase is a phase of the execution of a task.
+type Phase struct {
+	Fraction float64 // The fraction of the duration of the task.
+	Power    float64 // The power consumption relative to the one of the task.
+}
+
+// split splits each task that has phases into consecutive subtasks, one per
+// phase, on the same core. The result is a schedule wherein each task draws a
+// constant power, which all the algorithms operate on.
+func (self *Power) split(power []float64, schedule *time.Schedule) ([]float64, *time.Schedule) {
+	if self.phases == nil {
+		return power, schedule
+	}
+	schedule, owners, factors := self.phase(schedule)
+	result := make([]float64, len(owners))
+	for i, o := range owners {
+		result[i] = power[o] * factors[i]
+	}
+	return result, schedule
+}
+
+// phase splits each task that has phases into subtasks as split does. The
+// outputs are the resulting schedule, the task each subtask belongs to, and the
+// factor of each subtask that scales the power consumption of its task.
+func (self *Power) phase(schedule *time.Schedule) (*time.Schedule, []uint, []float64) {
+	nt := schedule.Tasks
+
+	result := *schedule
+	result.Tasks = 0
+	result.Mapping = make([]uint, 0, nt)
+	result.Start = make([]float64, 0, nt)
+	result.Finish = make([]float64, 0, nt)
+
+	owners, factors := make([]uint, 0, nt), make([]float64, 0, nt)
+	push := func(i uint, s, f, c float64) {
+		result.Tasks++
+		result.Mapping = append(result.Mapping, schedule.Mapping[i])
+		result.Start = append(result.Start, s)
+		result.Finish = append(result.Finish, f)
+		owners = append(owners, i)
+		factors = append(factors, c)
+	}
+
+	for i := uint(0); i < nt; i++ {
+		s, f := schedule.Start[i], schedule.Finish[i]
+		if i >= uint(len(self.phases)) || len(self.phases[i]) == 0 {
+			push(i, s, f, 1)
+			continue
+		}
+		phases := self.phases[i]
+		start, fraction := s, 0.0
+		for k, phase := range phases {
+			fraction += phase.Fraction
+			finish := s + fraction*(f-s)
+			if k == len(phases)-1 {
+				finish = f
+			}
+			push(i, start, finish, phase.Power)
+			start = finish
+		}
+	}
+
+	return &result, owners, factors
+}
@@ -0,0 +1,76 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestWithPhases(t *testing.T) {
+	const (
+		ε = 1e-9
+	)
+
+	power, schedule := prepareCustom([][]float64{{2}, {4}},
+		[]uint{0, 0}, []uint{0, 1}, []float64{0, 0}, []float64{2, 2})
+
+	phases := [][]Phase{{{Fraction: 0.25, Power: 1}, {Fraction: 0.75, Power: 0.5}}, nil}
+	power = New(power.platform, power.application, WithPhases(phases))
+	assert.Success(power.Validate(schedule), t)
+
+	P, ΔT := power.Partition(schedule, ε)
+	assert.Equal(P, []float64{2, 4, 1, 4}, t)
+	assert.Equal(ΔT, []float64{0.5, 1.5}, t)
+
+	P, count := power.Sample(schedule, 0.5, 4)
+	assert.Equal(P, []float64{2, 4, 1, 4, 1, 4, 1, 4}, t)
+	assert.Equal(count, uint(4), t)
+
+	compute, result := power.Progress(schedule), make([]float64, 2)
+	compute(0.25, result)
+	assert.Equal(result, []float64{2, 4}, t)
+	compute(1, result)
+	assert.Equal(result, []float64{1, 4}, t)
+
+	assert.Equal(power.Distribute(schedule), PowerVector{2, 4}, t)
+
+	power = New(power.platform, power.application, WithPhases(phases[:1]))
+	assert.Failure(power.Validate(schedule), t)
+}
+
+func TestWithPhasesConsistency(t *testing.T) {
+	const (
+		ε  = 1e-9
+		Δt = 1e-5
+	)
+
+	power, schedule := prepare("002_040")
+	ns := uint(schedule.Span / Δt)
+
+	phases := make([][]Phase, schedule.Tasks)
+	for i := range phases {
+		phases[i] = []Phase{{Fraction: 0.5, Power: 2}, {Fraction: 0.5, Power: 0}}
+	}
+	nominal := power
+	power = New(power.platform, power.application, WithPhases(phases))
+	assert.Success(power.Validate(schedule), t)
+
+	P, _ := power.Sample(schedule, Δt, ns)
+	Q := make([]float64, 0, len(P))
+	power.SampleFunc(schedule, Δt, ns, func(_ uint, row []float64) {
+		Q = append(Q, row...)
+	})
+	assert.Equal(Q, P, t)
+
+	assert.Success(power.CheckConsistency(schedule, ε, Δt, ns, 1e-6), t)
+
+	assert.Close(power.Energy(schedule), nominal.Energy(schedule), 1e-10, t)
+	assert.Close(power.EnergyPerTask(schedule), nominal.EnergyPerTask(schedule), 1e-10, t)
+
+	peaks, _ := power.PeakPerCore(schedule)
+	nominals, _ := nominal.PeakPerCore(schedule)
+	for j := range peaks {
+		nominals[j] *= 2
+	}
+	assert.Close(peaks, nominals, 1e-10, t)
+}
@@ -0,0 +1,65 @@
+package dynamic
+
+import (
+	"sort"
+
+	"github.com/turing-complete/time"
+)
+
+// Piecewise returns a function for computing the power consumption at an
+// arbitrary time moment. Unlike Progress, the profile is precomputed with the
+// same variable time step as Partition does, and each query is answered via a
+// binary search over the time moments of power switches. Each step is treated
+// as a half-open interval except for the last one, which includes the end of
+// the span. The parts of the span [0, Span] that precede and follow the steps
+// are covered by additional steps at the static and idle power consumption,
+// whose idle part is evaluated at the start of each of them, and the power
+// consumption outside the span is zero.
+func (self *Power) Piecewise(schedule *time.Schedule, ε float64) func(float64, []float64) {
+	nc := schedule.Cores
+
+	P, ΔT := self.Partition(schedule, ε)
+	_, pruned := self.prune(schedule)
+
+	start := origin(pruned)
+	finish := start + sum(ΔT)
+
+	breaks := make([]float64, 0, len(ΔT)+3)
+	levels := make([]float64, 0, len(P)+2*int(nc))
+	rest := func(time float64) {
+		for j := uint(0); j < nc; j++ {
+			levels = append(levels, self.resting(j, time))
+		}
+	}
+	if start > 0 {
+		breaks = append(breaks, 0)
+		rest(0)
+	}
+	breaks = append(breaks, start)
+	for i := range ΔT {
+		breaks = append(breaks, breaks[len(breaks)-1]+ΔT[i])
+	}
+	levels = append(levels, P...)
+	if schedule.Span > finish {
+		breaks = append(breaks, schedule.Span)
+		rest(finish)
+	}
+	ns := len(breaks) - 1
+
+	return func(time float64, result []float64) {
+		k := uint(sort.SearchFloat64s(breaks, time))
+		if k < uint(ns+1) && breaks[k] == time {
+			k++
+		}
+		if k == uint(ns+1) && ns > 0 && time == breaks[ns] {
+			k = uint(ns)
+		}
+		if k == 0 || k > uint(ns) {
+			for j := uint(0); j < nc; j++ {
+				result[j] = 0
+			}
+			return
+		}
+		copy(result[:nc], levels[(k-1)*nc:k*nc])
+	}
+}
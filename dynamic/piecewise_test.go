@@ -0,0 +1,50 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestPiecewise(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+	nc, ns := schedule.Cores, uint(len(fixturePartition.ΔT))
+	piecewise := power.Piecewise(schedule, ε)
+
+	P := make([]float64, nc*ns)
+	for i, time := uint(0), 0.0; i < ns; i++ {
+		piecewise(time+fixturePartition.ΔT[i]/2, P[i*nc:(i+1)*nc])
+		time += fixturePartition.ΔT[i]
+	}
+	assert.Equal(P, fixturePartition.P, t)
+
+	piecewise(-1, P[:nc])
+	assert.Equal(P[:nc], []float64{0, 0}, t)
+	piecewise(schedule.Span+1, P[:nc])
+	assert.Equal(P[:nc], []float64{0, 0}, t)
+	piecewise(0, P[:nc])
+	assert.Equal(P[:nc], fixturePartition.P[:nc], t)
+
+	power, schedule = prepareCustom([][]float64{{2}, {3}}, []uint{0},
+		[]uint{0}, []float64{1}, []float64{2})
+	schedule.Span = 3
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 0.5}),
+		WithConstantIdlePower([]float64{0.25, 0.25}))
+
+	piecewise = power.Piecewise(schedule, ε)
+	progress := power.Progress(schedule)
+	Q := make([]float64, nc)
+	for _, time := range []float64{0, 0.5, 1.5, 2.5, 3} {
+		piecewise(time, P[:nc])
+		progress(time, Q)
+		assert.Equal(P[:nc], Q, t)
+	}
+	piecewise(0.5, P[:nc])
+	assert.Equal(P[:nc], []float64{1.25, 0.75}, t)
+	piecewise(3.5, P[:nc])
+	assert.Equal(P[:nc], []float64{0, 0}, t)
+}
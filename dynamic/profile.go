@@ -0,0 +1,240 @@
+package dynamic
+
+import (
+	"errors"
+	"math"
+
+	"github.com/turing-complete/time"
+)
+
+// Profile is a power profile.
+type Profile struct {
+	Cores uint // The number of cores.
+	Steps uint // The number of time steps.
+
+	P  []float64 // The power of core j at step i stored at P[i*Cores+j].
+	ΔT []float64 // The duration of each time step.
+
+	Origin float64 // The start time of the first time step.
+}
+
+// PartitionProfile is the same as Partition except that the result is returned
+// as a profile.
+func (self *Power) PartitionProfile(schedule *time.Schedule, ε float64) *Profile {
+	P, ΔT := self.Partition(schedule, ε)
+	return &Profile{
+		Cores:  schedule.Cores,
+		Steps:  uint(len(ΔT)),
+		P:      P,
+		ΔT:     ΔT,
+		Origin: origin(schedule),
+	}
+}
+
+// SampleProfile is the same as Sample except that the result is returned as a
+// profile.
+func (self *Power) SampleProfile(schedule *time.Schedule, Δt float64, ns uint) *Profile {
+	P, _ := self.Sample(schedule, Δt, ns)
+	return uniform(P, schedule.Cores, Δt)
+}
+
+// Equal checks if two profiles are identical.
+func (self *Profile) Equal(other *Profile) bool {
+	return self.AlmostEqual(other, 0)
+}
+
+// AlmostEqual checks if two profiles have the same dimensions and differ by at
+// most tol in each power value, in each step duration, and in the origin.
+func (self *Profile) AlmostEqual(other *Profile, tol float64) bool {
+	if self == nil || other == nil {
+		return self == other
+	}
+	if self.Cores != other.Cores || self.Steps != other.Steps {
+		return false
+	}
+	return almostEqual(self.P, other.P, tol) && almostEqual(self.ΔT, other.ΔT, tol) &&
+		almostEqual([]float64{self.Origin}, []float64{other.Origin}, tol)
+}
+
+// At returns the power consumption of a core at a time step.
+func (self *Profile) At(core, step uint) float64 {
+	return self.P[step*self.Cores+core]
+}
+
+// Row returns the power consumption of all cores at a time step. The result
+// shares memory with the profile.
+func (self *Profile) Row(step uint) []float64 {
+	nc := self.Cores
+	return self.P[step*nc : (step+1)*nc]
+}
+
+// Column returns the power consumption of a core over all time steps. Since
+// the profile is stored step by step, the result is a copy.
+func (self *Profile) Column(core uint) []float64 {
+	nc, ns := self.Cores, self.Steps
+	column := make([]float64, ns)
+	for i := uint(0); i < ns; i++ {
+		column[i] = self.P[i*nc+core]
+	}
+	return column
+}
+
+// Raw returns the underlying power values in the layout used by Partition and
+// Sample, that is, with the power of core j at step i stored at i*Cores+j.
+func (self *Profile) Raw() []float64 {
+	return self.P
+}
+
+// Bounds returns the time interval covered by the profile and the range of the
+// power consumption over all cells. For an empty profile, the outputs are zero.
+func (self *Profile) Bounds() (float64, float64, float64, float64) {
+	if self.Steps == 0 || len(self.P) == 0 {
+		return 0, 0, 0, 0
+	}
+
+	tMin, tMax := self.Origin, self.Origin+sum(self.ΔT)
+
+	pMin, pMax := math.Inf(1), math.Inf(-1)
+	for _, p := range self.P {
+		pMin, pMax = math.Min(pMin, p), math.Max(pMax, p)
+	}
+
+	return tMin, tMax, pMin, pMax
+}
+
+// Sparsity returns the fraction of the cells of the profile whose power
+// consumption is zero.
+func (self *Profile) Sparsity() float64 {
+	if len(self.P) == 0 {
+		return 0
+	}
+	zeros := 0
+	for _, p := range self.P {
+		if p == 0 {
+			zeros++
+		}
+	}
+	return float64(zeros) / float64(len(self.P))
+}
+
+// ClipNonNegative returns a copy of the profile with negative power values
+// replaced with zero.
+func (self *Profile) ClipNonNegative() *Profile {
+	result := *self
+	result.P = make([]float64, len(self.P))
+	result.ΔT = append([]float64(nil), self.ΔT...)
+	for i, p := range self.P {
+		result.P[i] = math.Max(p, 0)
+	}
+	return &result
+}
+
+// ConvolveExp returns the convolution of the power consumption of each core
+// with the impulse response (1/τ)·exp(-t/τ) of a first-order RC circuit, which
+// is a rough approximation of the temperature rise. The power consumption is
+// assumed to be zero before the profile starts and constant within each step,
+// and the result at each step is the response at the end of the step. For
+// sampled profiles, whose steps are uniform, this amounts to a single-pole
+// recursive filter.
+func (self *Profile) ConvolveExp(τ float64) *Profile {
+	nc := self.Cores
+
+	result := *self
+	result.P = make([]float64, len(self.P))
+	result.ΔT = append([]float64(nil), self.ΔT...)
+
+	for i := uint(0); i < self.Steps; i++ {
+		α := math.Exp(-self.ΔT[i] / τ)
+		for j := uint(0); j < nc; j++ {
+			last := 0.0
+			if i > 0 {
+				last = result.P[(i-1)*nc+j]
+			}
+			result.P[i*nc+j] = α*last + (1-α)*self.P[i*nc+j]
+		}
+	}
+
+	return &result
+}
+
+// Window returns the part of a profile that covers the time interval [t0, t1).
+// The durations of the first and last time steps are clipped to the interval.
+func (self *Profile) Window(t0, t1 float64) *Profile {
+	nc := self.Cores
+
+	result := &Profile{Cores: nc, Origin: math.Max(t0, self.Origin)}
+
+	for i, time := uint(0), self.Origin; i < self.Steps && time < t1; i++ {
+		s, f := math.Max(time, t0), math.Min(time+self.ΔT[i], t1)
+		time += self.ΔT[i]
+		if f <= s {
+			continue
+		}
+		if result.Steps == 0 {
+			result.Origin = s
+		}
+		result.Steps++
+		result.P = append(result.P, self.P[i*nc:(i+1)*nc]...)
+		result.ΔT = append(result.ΔT, f-s)
+	}
+
+	return result
+}
+
+// StackProfiles combines profiles sharing the same time steps into a single
+// profile whose cores are the cores of the given profiles in order.
+func StackProfiles(profiles ...*Profile) (*Profile, error) {
+	if len(profiles) == 0 {
+		return nil, errors.New("there should be at least one profile")
+	}
+
+	first := profiles[0]
+	ns := first.Steps
+
+	nc := uint(0)
+	for _, profile := range profiles {
+		if profile.Steps != ns || profile.Origin != first.Origin ||
+			!almostEqual(profile.ΔT, first.ΔT, 0) {
+
+			return nil, errors.New("the profiles should have the same time steps")
+		}
+		nc += profile.Cores
+	}
+
+	result := &Profile{
+		Cores:  nc,
+		Steps:  ns,
+		P:      make([]float64, 0, nc*ns),
+		ΔT:     append([]float64(nil), first.ΔT...),
+		Origin: first.Origin,
+	}
+	for i := uint(0); i < ns; i++ {
+		for _, profile := range profiles {
+			kc := profile.Cores
+			result.P = append(result.P, profile.P[i*kc:(i+1)*kc]...)
+		}
+	}
+
+	return result, nil
+}
+
+func uniform(P []float64, nc uint, Δt float64) *Profile {
+	ns := uint(len(P)) / nc
+	ΔT := make([]float64, ns)
+	for i := range ΔT {
+		ΔT[i] = Δt
+	}
+	return &Profile{Cores: nc, Steps: ns, P: P, ΔT: ΔT}
+}
+
+func almostEqual(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !(math.Abs(a[i]-b[i]) <= tol) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package dynamic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestProfileEqual(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+
+	profile := power.PartitionProfile(schedule, ε)
+	assert.Equal(profile.Equal(profile), true, t)
+	assert.Equal(profile.Equal(power.PartitionProfile(schedule, ε)), true, t)
+
+	other := power.PartitionProfile(schedule, ε)
+	other.P[1] += 1e-10
+	assert.Equal(profile.Equal(other), false, t)
+	assert.Equal(profile.AlmostEqual(other, 1e-9), true, t)
+	assert.Equal(profile.AlmostEqual(other, 1e-11), false, t)
+
+	other = power.PartitionProfile(schedule, ε)
+	other.ΔT[1] += 1e-10
+	assert.Equal(profile.AlmostEqual(other, 1e-11), false, t)
+
+	other = power.SampleProfile(schedule, 1e-3, 440)
+	assert.Equal(profile.AlmostEqual(other, 1), false, t)
+	assert.Equal(profile.Equal(nil), false, t)
+}
+
+func TestProfileAccessors(t *testing.T) {
+	profile := &Profile{
+		Cores: 2,
+		Steps: 3,
+		P:     []float64{1, 2, 3, 4, 5, 6},
+		ΔT:    []float64{1, 1, 1},
+	}
+
+	assert.Equal(profile.At(0, 0), 1.0, t)
+	assert.Equal(profile.At(1, 0), 2.0, t)
+	assert.Equal(profile.At(0, 2), 5.0, t)
+	assert.Equal(profile.Row(1), []float64{3, 4}, t)
+	assert.Equal(profile.Column(1), []float64{2, 4, 6}, t)
+	assert.Equal(profile.Raw(), profile.P, t)
+
+	profile.Row(2)[0] = 42
+	assert.Equal(profile.At(0, 2), 42.0, t)
+
+	profile.Column(0)[0] = 42
+	assert.Equal(profile.At(0, 0), 1.0, t)
+}
+
+func TestProfileWindow(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+	profile := power.PartitionProfile(schedule, ε)
+
+	integrate := func(profile *Profile) []float64 {
+		nc := profile.Cores
+		energy := make([]float64, nc)
+		for i := uint(0); i < profile.Steps; i++ {
+			for j := uint(0); j < nc; j++ {
+				energy[j] += profile.P[i*nc+j] * profile.ΔT[i]
+			}
+		}
+		return energy
+	}
+
+	test := func(t0, t1 float64) {
+		window := profile.Window(t0, t1)
+		assert.Close(integrate(window), power.EnergyWindow(schedule, t0, t1), 1e-12, t)
+	}
+
+	test(0, schedule.Span)
+	test(0.1, 0.2)
+	test(0.0305, 0.3333)
+	test(-1, 0.05)
+	test(0.4, 1)
+
+	window := profile.Window(0.0305, 0.3333)
+	assert.Equal(window.Origin, 0.0305, t)
+	assert.Close(sum(window.ΔT), 0.3333-0.0305, 1e-14, t)
+	assert.Equal(profile.Window(1, 2).Steps, uint(0), t)
+}
+
+func TestProfileClipNonNegative(t *testing.T) {
+	profile := &Profile{
+		Cores:  2,
+		Steps:  2,
+		P:      []float64{1, -1e-16, 2, 3},
+		ΔT:     []float64{1, 2},
+		Origin: 1,
+	}
+
+	result := profile.ClipNonNegative()
+	assert.Equal(result.P, []float64{1, 0, 2, 3}, t)
+	assert.Equal(result.ΔT, profile.ΔT, t)
+	assert.Equal(result.Origin, profile.Origin, t)
+	assert.Equal(profile.P[1], -1e-16, t)
+}
+
+func TestStackProfiles(t *testing.T) {
+	a := &Profile{Cores: 2, Steps: 2, P: []float64{1, 2, 3, 4}, ΔT: []float64{1, 2}}
+	b := &Profile{Cores: 2, Steps: 2, P: []float64{5, 6, 7, 8}, ΔT: []float64{1, 2}}
+
+	result, err := StackProfiles(a, b)
+	assert.Success(err, t)
+	assert.Equal(result.Cores, uint(4), t)
+	assert.Equal(result.Steps, uint(2), t)
+	assert.Equal(result.P, []float64{1, 2, 5, 6, 3, 4, 7, 8}, t)
+	assert.Equal(result.ΔT, []float64{1, 2}, t)
+
+	b.ΔT[1] = 3
+	_, err = StackProfiles(a, b)
+	assert.Failure(err, t)
+
+	_, err = StackProfiles()
+	assert.Failure(err, t)
+}
+
+func TestProfileConvolveExp(t *testing.T) {
+	const (
+		Δt = 0.1
+		ns = 100
+		τ  = 2
+	)
+
+	P := make([]float64, 2*ns)
+	for i := 0; i < ns; i++ {
+		P[2*i] = 3
+	}
+
+	result := uniform(P, 2, Δt).ConvolveExp(τ)
+	for i := 0; i < ns; i++ {
+		time := float64(i+1) * Δt
+		assert.Close(result.P[2*i], 3*(1-math.Exp(-time/τ)), 1e-12, t)
+		assert.Equal(result.P[2*i+1], 0.0, t)
+	}
+}
+
+func TestProfileSparsity(t *testing.T) {
+	profile := &Profile{
+		Cores: 4,
+		Steps: 2,
+		P:     []float64{1, 0, 0, 0, 0, 2, 0, 3},
+		ΔT:    []float64{1, 1},
+	}
+	assert.Equal(profile.Sparsity(), 0.625, t)
+	assert.Equal((&Profile{}).Sparsity(), 0.0, t)
+}
+
+func TestProfileBounds(t *testing.T) {
+	profile := &Profile{
+		Cores:  2,
+		Steps:  3,
+		P:      []float64{1, -2, 3, 4, 0, 7},
+		ΔT:     []float64{1, 2, 0.5},
+		Origin: 1,
+	}
+
+	tMin, tMax, pMin, pMax := profile.Bounds()
+	assert.Equal([]float64{tMin, tMax, pMin, pMax}, []float64{1, 4.5, -2, 7}, t)
+
+	tMin, tMax, pMin, pMax = (&Profile{}).Bounds()
+	assert.Equal([]float64{tMin, tMax, pMin, pMax}, []float64{0, 0, 0, 0}, t)
+}
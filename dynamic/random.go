@@ -0,0 +1,84 @@
+package dynamic
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/turing-complete/time"
+)
+
+// Sampler draws a realization of the power consumption of a task given the
+// power consumption computed for the task without uncertainty.
+type Sampler func(task uint, power float64, rng *rand.Rand) float64
+
+// SampleRandom is the same as Sample except that the power consumption of the
+// tasks is a realization of their uncertain power consumption drawn using a
+// source of random numbers. The distribution is set via WithPowerSampler or
+// WithPowerVariance; if neither is set, the result is the same as the one of
+// Sample, and no numbers are drawn.
+func (self *Power) SampleRandom(schedule *time.Schedule, Δt float64, ns uint,
+	source rand.Source) ([]float64, uint) {
+
+	P := make([]float64, schedule.Cores*ns)
+	power, schedule := self.split(self.realize(schedule, rand.New(source)), schedule)
+	return P, self.sampleInto(schedule, power, Δt, ns, P)
+}
+
+// Expectation computes the expected power profile with respect to a sampling
+// interval Δt when the power consumption of the tasks is uncertain as set via
+// WithPowerVariance. Since the mean of the power consumption of each task is
+// the power consumption computed without uncertainty, the result is the same
+// as the one of Sample; a sampler set via WithPowerSampler is not taken into
+// account.
+func (self *Power) Expectation(schedule *time.Schedule, Δt float64, ns uint) ([]float64, uint) {
+	return self.Sample(schedule, Δt, ns)
+}
+
+// Variance computes the variance of the power profile with respect to a
+// sampling interval Δt when the power consumption of the tasks is uncertain as
+// set via WithPowerVariance. The power consumption of the tasks is assumed to
+// be independent; hence, the variance at each sample is the sum of the
+// variances of the tasks running at the sample, and the static and idle power
+// contribute nothing. A sampler set via WithPowerSampler is not taken into
+// account. The second output is the same as the one of Sample.
+func (self *Power) Variance(schedule *time.Schedule, Δt float64, ns uint) ([]float64, uint) {
+	V := make([]float64, schedule.Cores*ns)
+	variance := self.variance
+	if variance == nil {
+		variance = make([]float64, schedule.Tasks)
+	}
+	if self.phases != nil {
+		phased, owners, factors := self.phase(schedule)
+		scaled := make([]float64, len(owners))
+		for k, i := range owners {
+			scaled[k] = variance[i] * factors[k] * factors[k]
+		}
+		variance, schedule = scaled, phased
+	}
+	return V, fillSample(V, variance, schedule, Δt, ns, self.parallel())
+}
+
+func (self *Power) realize(schedule *time.Schedule, rng *rand.Rand) []float64 {
+	power := self.Distribute(schedule)
+	switch {
+	case self.sampler != nil:
+		for i := range power {
+			power[i] = self.sampler(uint(i), power[i], rng)
+		}
+	case self.variance != nil:
+		deviation := make([]float64, len(power))
+		for i := range power {
+			deviation[i] = math.Sqrt(self.variance[i])
+		}
+		perturb(power, deviation, rng)
+	}
+	return power
+}
+
+// perturb adds to the power consumption of each task Gaussian noise with zero
+// mean and the given standard deviation.
+func perturb(power, deviation []float64, rng *rand.Rand) {
+	for i := range power {
+		power[i] += deviation[i] * rng.NormFloat64()
+	}
+}
@@ -0,0 +1,129 @@
+package dynamic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestSampleRandom(t *testing.T) {
+	const (
+		Δt = 1e-3
+		ns = 440
+	)
+
+	power, schedule := prepare("002_040")
+	nt := schedule.Tasks
+
+	P, count := power.Sample(schedule, Δt, ns)
+	R, rcount := power.SampleRandom(schedule, Δt, ns, rand.NewSource(0))
+	assert.Equal(R, P, t)
+	assert.Equal(rcount, count, t)
+
+	variance := make([]float64, nt)
+	for i := range variance {
+		variance[i] = 1
+	}
+	power = New(power.platform, power.application, WithPowerVariance(variance))
+
+	R1, _ := power.SampleRandom(schedule, Δt, ns, rand.NewSource(42))
+	R2, _ := power.SampleRandom(schedule, Δt, ns, rand.NewSource(42))
+	R3, _ := power.SampleRandom(schedule, Δt, ns, rand.NewSource(7))
+	assert.Equal(R1, R2, t)
+	assert.Equal(almostEqual(R1, R3, 0), false, t)
+	assert.Equal(almostEqual(R1, P, 0), false, t)
+
+	double := func(_ uint, power float64, _ *rand.Rand) float64 {
+		return 2 * power
+	}
+	power = New(power.platform, power.application, WithPowerVariance(variance),
+		WithPowerSampler(double))
+
+	R, _ = power.SampleRandom(schedule, Δt, ns, rand.NewSource(0))
+	for i := range P {
+		assert.Equal(math.Abs(R[i]-2*P[i]) < 1e-12, true, t)
+	}
+}
+
+func TestPowerVarianceRelative(t *testing.T) {
+	power, schedule := prepare("002_040")
+	nt := schedule.Tasks
+
+	nominal := power.Distribute(schedule)
+	sigma, variance := make([]float64, nt), make([]float64, nt)
+	for i := range sigma {
+		sigma[i] = 0.1
+		variance[i] = (sigma[i] * nominal[i]) * (sigma[i] * nominal[i])
+	}
+
+	P := power.DistributeRandom(schedule, rand.New(rand.NewSource(42)), sigma)
+	power = New(power.platform, power.application, WithPowerVariance(variance))
+	assert.Close(power.realize(schedule, rand.New(rand.NewSource(42))), []float64(P), 1e-12, t)
+}
+
+func TestExpectationVariance(t *testing.T) {
+	const (
+		Δt = 1e-3
+		ns = 440
+	)
+
+	power, schedule := prepare("002_040")
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	V, count := power.Variance(schedule, Δt, ns)
+	assert.Equal(V, make([]float64, nc*ns), t)
+
+	variance := make([]float64, nt)
+	for i := range variance {
+		variance[i] = float64(i + 1)
+	}
+	power = New(power.platform, power.application, WithPowerVariance(variance))
+
+	P, _ := power.Sample(schedule, Δt, ns)
+	E, ecount := power.Expectation(schedule, Δt, ns)
+	assert.Equal(E, P, t)
+	assert.Equal(ecount, count, t)
+
+	expected := make([]float64, nc*ns)
+	for i := uint(0); i < nt; i++ {
+		s, f := grid(schedule, i, Δt, count)
+		for k := s; k < f; k++ {
+			expected[k*nc+schedule.Mapping[i]] += variance[i]
+		}
+	}
+	V, _ = power.Variance(schedule, Δt, ns)
+	assert.Equal(V, expected, t)
+}
+
+func TestVarianceMonteCarlo(t *testing.T) {
+	const (
+		Δt = 0.5
+		ns = 4
+		nm = 10000
+	)
+
+	power, schedule := prepareCustom([][]float64{{1, 2}, {3, 4}},
+		[]uint{0, 1, 0, 1}, []uint{0, 1, 1, 0},
+		[]float64{0, 0, 1, 1}, []float64{1, 1, 2, 2})
+	power = New(power.platform, power.application,
+		WithPowerVariance([]float64{0.25, 1, 4, 0.5}))
+
+	E, _ := power.Expectation(schedule, Δt, ns)
+	V, _ := power.Variance(schedule, Δt, ns)
+
+	source := rand.NewSource(0)
+	mean, square := make([]float64, len(E)), make([]float64, len(E))
+	for m := 0; m < nm; m++ {
+		P, _ := power.SampleRandom(schedule, Δt, ns, source)
+		for i, p := range P {
+			mean[i] += p / nm
+			square[i] += p * p / nm
+		}
+	}
+	for i := range E {
+		assert.Equal(math.Abs(mean[i]-E[i]) < 0.1, true, t)
+		assert.Equal(math.Abs(square[i]-mean[i]*mean[i]-V[i]) < 0.2, true, t)
+	}
+}
@@ -0,0 +1,42 @@
+package dynamic
+
+// ResampleOnto averages an external signal over each interval of a variable
+// time grid such as the one produced by Partition. The signal is given by its
+// values at increasing time moments and is assumed to hold each value until
+// the next moment; the first and last values are extended indefinitely. The
+// grid is assumed to start at time zero; for profiles that do not start at
+// zero, the time moments of the signal should be shifted by the origin of the
+// profile beforehand.
+func ResampleOnto(ΔT []float64, times, values []float64) []float64 {
+	ns, nv := len(ΔT), len(times)
+
+	result := make([]float64, ns)
+	if nv == 0 {
+		return result
+	}
+
+	k, a := 0, 0.0
+	for i := 0; i < ns; i++ {
+		for k+1 < nv && times[k+1] <= a {
+			k++
+		}
+
+		b := a + ΔT[i]
+		if b <= a {
+			result[i] = values[k]
+			continue
+		}
+
+		integral, t, m := 0.0, a, k
+		for m+1 < nv && times[m+1] < b {
+			integral += values[m] * (times[m+1] - t)
+			t, m = times[m+1], m+1
+		}
+		integral += values[m] * (b - t)
+
+		result[i] = integral / ΔT[i]
+		a = b
+	}
+
+	return result
+}
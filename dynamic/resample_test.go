@@ -0,0 +1,20 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestResampleOnto(t *testing.T) {
+	ΔT := []float64{0.5, 1.5, 1, 2}
+
+	result := ResampleOnto(ΔT, []float64{0, 0.7, 2.3, 3.9}, []float64{42, 42, 42, 42})
+	assert.Close(result, []float64{42, 42, 42, 42}, 1e-14, t)
+
+	result = ResampleOnto(ΔT, []float64{1, 2.5}, []float64{2, 4})
+	assert.Close(result, []float64{2, 2, 3, 4}, 1e-14, t)
+
+	result = ResampleOnto(ΔT, nil, nil)
+	assert.Equal(result, []float64{0, 0, 0, 0}, t)
+}
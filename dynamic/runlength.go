@@ -0,0 +1,51 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+// Run is a time interval during which the power consumption of a core stays
+// constant.
+type Run struct {
+	Power    float64
+	Duration float64
+}
+
+// RunLength computes a power profile with the same variable time step as
+// Partition does and encodes the power consumption of each core as a sequence
+// of runs, merging consecutive steps with equal power. The runs of each core
+// cover the whole span of the schedule, including the idle time before the
+// first and after the last task, which is accounted for at the static and idle
+// power consumption.
+func (self *Power) RunLength(schedule *time.Schedule, ε float64) [][]Run {
+	nc := schedule.Cores
+
+	_, pruned := self.prune(schedule)
+	P, ΔT := self.Partition(schedule, ε)
+	ns := uint(len(ΔT))
+
+	start := origin(pruned)
+	finish := start + sum(ΔT)
+
+	runs := make([][]Run, nc)
+	for j := uint(0); j < nc; j++ {
+		extend := func(p, Δt float64) {
+			if Δt <= 0 {
+				return
+			}
+			if k := len(runs[j]) - 1; k >= 0 && runs[j][k].Power == p {
+				runs[j][k].Duration += Δt
+			} else {
+				runs[j] = append(runs[j], Run{Power: p, Duration: Δt})
+			}
+		}
+
+		extend(self.resting(j, 0), start)
+		for i := uint(0); i < ns; i++ {
+			extend(P[i*nc+j], ΔT[i])
+		}
+		extend(self.resting(j, finish), schedule.Span-finish)
+	}
+
+	return runs
+}
@@ -0,0 +1,40 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestRunLength(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2, 5}, {3, 5}},
+		[]uint{0, 1, 1, 1}, []uint{0, 1, 1, 1},
+		[]float64{0, 1, 2, 3}, []float64{2, 2, 3, 4})
+
+	runs := power.RunLength(schedule, ε)
+	assert.Equal(runs, [][]Run{
+		{{Power: 2, Duration: 2}, {Power: 0, Duration: 2}},
+		{{Power: 0, Duration: 1}, {Power: 5, Duration: 3}},
+	}, t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1, 1}))
+	schedule.Span = 5
+
+	runs = power.RunLength(schedule, ε)
+	assert.Equal(runs, [][]Run{
+		{{Power: 3, Duration: 2}, {Power: 1, Duration: 3}},
+		{{Power: 1, Duration: 1}, {Power: 6, Duration: 3}, {Power: 1, Duration: 1}},
+	}, t)
+
+	power = New(power.platform, power.application, WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	runs = power.RunLength(schedule, ε)
+	assert.Equal(runs, [][]Run{
+		{{Power: 2, Duration: 2}, {Power: 0.5, Duration: 3}},
+		{{Power: 0.25, Duration: 1}, {Power: 5, Duration: 3}, {Power: 0.25, Duration: 1}},
+	}, t)
+}
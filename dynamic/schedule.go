@@ -0,0 +1,123 @@
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/turing-complete/time"
+)
+
+// Normalize returns a copy of a schedule shifted in time so that the earliest
+// task starts at zero.
+//
+// The computations of the package measure time from zero; hence, a schedule
+// whose tasks share a common nonzero origin results in a leading run of idle
+// steps or samples. Origin handling is opt-in: such a schedule should be passed
+// through Normalize first.
+func Normalize(schedule *time.Schedule) *time.Schedule {
+	return shift(schedule, -origin(schedule))
+}
+
+// NormalizeRelativeFinish returns a copy of a schedule whose finish times are
+// given relative to the start times, that is, as durations, with the finish
+// times converted to absolute ones. The span is updated accordingly.
+//
+// The computations of the package interpret finish times as absolute time
+// moments, and nothing in a schedule indicates which convention it follows; a
+// schedule storing durations silently yields wrong results unless it is passed
+// through NormalizeRelativeFinish first.
+func NormalizeRelativeFinish(schedule *time.Schedule) *time.Schedule {
+	nt := schedule.Tasks
+
+	result := *schedule
+	result.Finish = make([]float64, nt)
+	result.Span = 0
+	for i := uint(0); i < nt; i++ {
+		result.Finish[i] = schedule.Start[i] + schedule.Finish[i]
+		if result.Finish[i] > result.Span {
+			result.Span = result.Finish[i]
+		}
+	}
+
+	return &result
+}
+
+// TaskRun is a task executed on a core for a certain duration.
+type TaskRun struct {
+	Task     uint
+	Duration float64
+}
+
+// FromCoreLists builds a schedule from ordered lists of tasks, one per core.
+// The tasks of each core are executed back to back starting from zero, and
+// the task indices of all the lists together should enumerate the tasks of the
+// application, that is, each index from zero up to the total number of runs
+// should appear exactly once; otherwise, an error is returned. The order of
+// the schedule is left empty since the lists carry no information about the
+// dependencies between the tasks.
+func FromCoreLists(lists [][]TaskRun) (*time.Schedule, error) {
+	nc, nt := uint(len(lists)), uint(0)
+	for _, list := range lists {
+		nt += uint(len(list))
+	}
+
+	schedule := &time.Schedule{
+		Cores:   nc,
+		Tasks:   nt,
+		Mapping: make([]uint, nt),
+		Start:   make([]float64, nt),
+		Finish:  make([]float64, nt),
+	}
+
+	seen := make([]bool, nt)
+	for j, list := range lists {
+		time := 0.0
+		for _, run := range list {
+			i := run.Task
+			if i >= nt {
+				return nil, fmt.Errorf("core %d runs task %d while there are %d tasks", j, i, nt)
+			}
+			if seen[i] {
+				return nil, fmt.Errorf("task %d is listed more than once", i)
+			}
+			seen[i] = true
+			schedule.Mapping[i] = uint(j)
+			schedule.Start[i] = time
+			time += run.Duration
+			schedule.Finish[i] = time
+		}
+		if time > schedule.Span {
+			schedule.Span = time
+		}
+	}
+
+	return schedule, nil
+}
+
+func origin(schedule *time.Schedule) float64 {
+	nt := schedule.Tasks
+	if nt == 0 {
+		return 0
+	}
+	start := schedule.Start[0]
+	for i := uint(1); i < nt; i++ {
+		if schedule.Start[i] < start {
+			start = schedule.Start[i]
+		}
+	}
+	return start
+}
+
+func shift(schedule *time.Schedule, Δ float64) *time.Schedule {
+	nt := schedule.Tasks
+
+	result := *schedule
+	result.Start = make([]float64, nt)
+	result.Finish = make([]float64, nt)
+	for i := uint(0); i < nt; i++ {
+		result.Start[i] = schedule.Start[i] + Δ
+		result.Finish[i] = schedule.Finish[i] + Δ
+	}
+	result.Span = schedule.Span + Δ
+
+	return &result
+}
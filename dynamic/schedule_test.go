@@ -0,0 +1,79 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	const (
+		Δt = 1e-3
+	)
+
+	power, schedule := prepare("002_040")
+
+	shifted := shift(schedule, 1)
+	assert.Equal(origin(shifted), 1.0, t)
+
+	normalized := Normalize(shifted)
+	assert.Equal(origin(normalized), 0.0, t)
+	assert.Close(normalized.Span, schedule.Span, 1e-14, t)
+
+	P, _ := power.Sample(normalized, Δt, 440)
+	assert.Equal(P, fixtureSample.P, t)
+}
+
+func TestFromCoreLists(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2, 3}, {4, 5}},
+		[]uint{0, 1, 1, 0}, []uint{0, 1, 0, 1},
+		[]float64{0, 0, 1, 2}, []float64{1, 2, 3, 3})
+
+	built, err := FromCoreLists([][]TaskRun{
+		{{Task: 0, Duration: 1}, {Task: 2, Duration: 2}},
+		{{Task: 1, Duration: 2}, {Task: 3, Duration: 1}},
+	})
+	assert.Success(err, t)
+	assert.Equal(built.Mapping, schedule.Mapping, t)
+	assert.Equal(built.Start, schedule.Start, t)
+	assert.Equal(built.Finish, schedule.Finish, t)
+	assert.Equal(built.Span, schedule.Span, t)
+
+	P1, ΔT1 := power.Partition(schedule, ε)
+	P2, ΔT2 := power.Partition(built, ε)
+	assert.Equal(P2, P1, t)
+	assert.Equal(ΔT2, ΔT1, t)
+
+	_, err = FromCoreLists([][]TaskRun{{{Task: 0, Duration: 1}}, {{Task: 0, Duration: 1}}})
+	assert.Failure(err, t)
+
+	_, err = FromCoreLists([][]TaskRun{{{Task: 0, Duration: 1}, {Task: 2, Duration: 1}}})
+	assert.Failure(err, t)
+	assert.Equal(err.Error(), "core 0 runs task 2 while there are 2 tasks", t)
+}
+
+func TestNormalizeRelativeFinish(t *testing.T) {
+	const (
+		Δt = 1e-3
+	)
+
+	power, schedule := prepare("002_040")
+
+	relative := *schedule
+	relative.Finish = make([]float64, schedule.Tasks)
+	for i := range relative.Finish {
+		relative.Finish[i] = schedule.Finish[i] - schedule.Start[i]
+	}
+
+	normalized := NormalizeRelativeFinish(&relative)
+	assert.Close(normalized.Finish, schedule.Finish, 1e-14, t)
+	assert.Close(normalized.Span, schedule.Span, 1e-14, t)
+
+	P1, _ := power.Sample(schedule, Δt, 440)
+	P2, _ := power.Sample(normalized, Δt, 440)
+	assert.Equal(P2, P1, t)
+}
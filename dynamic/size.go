@@ -0,0 +1,22 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+const (
+	sizeOfFloat = 8
+)
+
+// SampleSize returns the size in bytes of the power profile that Sample
+// produces for the given sampling interval and number of samples.
+func (self *Power) SampleSize(Δt float64, ns uint) uintptr {
+	return uintptr(uint(len(self.platform.Cores))*ns) * sizeOfFloat
+}
+
+// PartitionSize returns the size in bytes of the power profile that Partition
+// produces without computing the profile.
+func (self *Power) PartitionSize(schedule *time.Schedule, ε float64) uintptr {
+	ns := self.PartitionSteps(schedule, ε)
+	return uintptr(schedule.Cores*ns) * sizeOfFloat
+}
@@ -0,0 +1,30 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestSampleSize(t *testing.T) {
+	const (
+		Δt = 1e-3
+		ns = 440
+	)
+
+	power, schedule := prepare("002_040")
+
+	P, _ := power.Sample(schedule, Δt, ns)
+	assert.Equal(power.SampleSize(Δt, ns), uintptr(8*len(P)), t)
+}
+
+func TestPartitionSize(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+
+	P, _ := power.Partition(schedule, ε)
+	assert.Equal(power.PartitionSize(schedule, ε), uintptr(8*len(P)), t)
+}
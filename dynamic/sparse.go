@@ -0,0 +1,94 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+// SparseProfile is a power profile that stores only the cells with nonzero
+// power consumption in the compressed sparse row format.
+type SparseProfile struct {
+	Cores uint // The number of cores.
+	Steps uint // The number of time steps.
+
+	Offsets []uint    // The cells of step i are at [Offsets[i], Offsets[i+1]).
+	Indices []uint    // The core of each cell.
+	Values  []float64 // The power of each cell.
+
+	ΔT []float64 // The duration of each time step.
+
+	Origin float64 // The start time of the first time step.
+}
+
+// SampleSparse is the same as Sample except that the result is returned as a
+// sparse profile. The full profile is not constructed.
+func (self *Power) SampleSparse(schedule *time.Schedule, Δt float64, ns uint) *SparseProfile {
+	result := &SparseProfile{
+		Cores:   schedule.Cores,
+		Steps:   ns,
+		Offsets: make([]uint, 1, ns+1),
+		ΔT:      make([]float64, ns),
+	}
+	power, schedule := self.prune(schedule)
+	self.stream(power, schedule, Δt, ns, func(k uint, row []float64) {
+		result.append(row)
+		result.ΔT[k] = Δt
+	})
+
+	return result
+}
+
+// Sparse returns the profile in the sparse format.
+func (self *Profile) Sparse() *SparseProfile {
+	nc, ns := self.Cores, self.Steps
+
+	result := &SparseProfile{
+		Cores:   nc,
+		Steps:   ns,
+		Offsets: make([]uint, 1, ns+1),
+		ΔT:      append([]float64(nil), self.ΔT...),
+		Origin:  self.Origin,
+	}
+	for i := uint(0); i < ns; i++ {
+		result.append(self.P[i*nc : (i+1)*nc])
+	}
+
+	return result
+}
+
+// Row returns the cores with nonzero power consumption at a time step and the
+// corresponding power values. The slices are shared with the profile.
+func (self *SparseProfile) Row(step uint) ([]uint, []float64) {
+	s, f := self.Offsets[step], self.Offsets[step+1]
+	return self.Indices[s:f], self.Values[s:f]
+}
+
+// ToDense returns the profile in the dense format.
+func (self *SparseProfile) ToDense() *Profile {
+	nc, ns := self.Cores, self.Steps
+
+	result := &Profile{
+		Cores:  nc,
+		Steps:  ns,
+		P:      make([]float64, nc*ns),
+		ΔT:     append([]float64(nil), self.ΔT...),
+		Origin: self.Origin,
+	}
+	for i := uint(0); i < ns; i++ {
+		indices, values := self.Row(i)
+		for k, j := range indices {
+			result.P[i*nc+j] = values[k]
+		}
+	}
+
+	return result
+}
+
+func (self *SparseProfile) append(row []float64) {
+	for j, p := range row {
+		if p != 0 {
+			self.Indices = append(self.Indices, uint(j))
+			self.Values = append(self.Values, p)
+		}
+	}
+	self.Offsets = append(self.Offsets, uint(len(self.Values)))
+}
@@ -0,0 +1,75 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestSampleSparse(t *testing.T) {
+	const (
+		Δt = 1e-3
+		ns = 500
+	)
+
+	power, schedule := prepare("002_040")
+
+	test := func() {
+		P, _ := power.Sample(schedule, Δt, ns)
+
+		sparse := power.SampleSparse(schedule, Δt, ns)
+		assert.Equal(sparse.ToDense().Equal(uniform(P, schedule.Cores, Δt)), true, t)
+		assert.Equal(uniform(P, schedule.Cores, Δt).Sparse(), sparse, t)
+	}
+
+	test()
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	test()
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}),
+		WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	test()
+}
+
+func TestSparseProfileRow(t *testing.T) {
+	profile := &Profile{
+		Cores: 4,
+		Steps: 3,
+		P:     []float64{1, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 3},
+		ΔT:    []float64{1, 1, 1},
+	}
+
+	sparse := profile.Sparse()
+	assert.Equal(len(sparse.Values), 3, t)
+	assert.Equal(sparse.ToDense(), profile, t)
+
+	indices, values := sparse.Row(0)
+	assert.Equal(indices, []uint{0}, t)
+	assert.Equal(values, []float64{1}, t)
+
+	indices, values = sparse.Row(1)
+	assert.Equal(len(indices), 0, t)
+	assert.Equal(len(values), 0, t)
+
+	indices, values = sparse.Row(2)
+	assert.Equal(indices, []uint{1, 3}, t)
+	assert.Equal(values, []float64{2, 3}, t)
+}
+
+func TestSampleSparseMemory(t *testing.T) {
+	const (
+		Δt = 1e-3
+		ns = 1000
+	)
+
+	power, schedule := prepareCustom([][]float64{{1}, {1}, {1}, {1}, {1}, {1}, {1}, {1}},
+		[]uint{0}, []uint{3}, []float64{0}, []float64{1})
+
+	P, _ := power.Sample(schedule, Δt, ns)
+	sparse := power.SampleSparse(schedule, Δt, ns)
+
+	assert.Equal(len(sparse.Values)+len(sparse.Indices) < len(P)/2, true, t)
+}
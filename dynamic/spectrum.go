@@ -0,0 +1,83 @@
+package dynamic
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// DominantFrequency returns the frequency of the largest non-constant spectral
+// component of the total power consumption of all cores, measured in cycles per
+// unit of time. The profile should have uniform time steps as the ones of
+// Sample; the spectrum is computed via the fast Fourier transform with the
+// signal padded with zeros to a power of two.
+func (self *Profile) DominantFrequency() (float64, error) {
+	nc, ns := self.Cores, self.Steps
+	if ns < 2 {
+		return 0, errors.New("the profile should have at least two time steps")
+	}
+
+	Δt := self.ΔT[0]
+	for _, δ := range self.ΔT {
+		if δ != Δt {
+			return 0, errors.New("the profile should have uniform time steps")
+		}
+	}
+
+	n := uint(1)
+	for n < ns {
+		n <<= 1
+	}
+
+	total := make([]float64, ns)
+	mean := 0.0
+	for i := uint(0); i < ns; i++ {
+		total[i] = sum(self.P[i*nc : (i+1)*nc])
+		mean += total[i]
+	}
+	mean /= float64(ns)
+
+	data := make([]complex128, n)
+	for i := uint(0); i < ns; i++ {
+		data[i] = complex(total[i]-mean, 0)
+	}
+	fft(data)
+
+	k, max := uint(0), 0.0
+	for i := uint(1); i <= n/2; i++ {
+		if magnitude := cmplx.Abs(data[i]); magnitude > max {
+			k, max = i, magnitude
+		}
+	}
+
+	return float64(k) / (float64(n) * Δt), nil
+}
+
+// fft computes the discrete Fourier transform of data in place. The length of
+// data should be a power of two.
+func fft(data []complex128) {
+	n := len(data)
+
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		ω := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				a, b := data[start+k], w*data[start+k+size/2]
+				data[start+k], data[start+k+size/2] = a+b, a-b
+				w *= ω
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package dynamic
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestDominantFrequency(t *testing.T) {
+	const (
+		Δt = 1e-3
+		ns = 1000
+		f  = 50
+	)
+
+	P := make([]float64, 2*ns)
+	for i := 0; i < ns; i++ {
+		P[2*i] = 10 + 3*math.Sin(2*math.Pi*f*float64(i)*Δt)
+		P[2*i+1] = 5
+	}
+
+	frequency, err := uniform(P, 2, Δt).DominantFrequency()
+	assert.Success(err, t)
+
+	bin := 1 / (1024 * Δt)
+	assert.Equal(math.Abs(frequency-f) <= bin, true, t)
+
+	profile := &Profile{Cores: 1, Steps: 2, P: []float64{1, 2}, ΔT: []float64{1, 2}}
+	_, err = profile.DominantFrequency()
+	assert.Failure(err, t)
+}
+
+func TestFFT(t *testing.T) {
+	data := []complex128{1, 2, 3, 4, 0, -1, 2, 5}
+
+	expected := make([]complex128, len(data))
+	for k := range expected {
+		for i := range data {
+			θ := -2 * math.Pi * float64(k*i) / float64(len(data))
+			expected[k] += data[i] * cmplx.Exp(complex(0, θ))
+		}
+	}
+
+	fft(data)
+	for k := range data {
+		assert.Close(cmplx.Abs(data[k]-expected[k]), 0.0, 1e-12, t)
+	}
+}
@@ -0,0 +1,189 @@
+package dynamic
+
+import (
+	"math"
+
+	"github.com/turing-complete/time"
+)
+
+// PartitionFunc computes a power profile with the same variable time step as
+// Partition does but passes each time step to a function instead of storing
+// it. The function receives the duration of the step and the power consumption
+// of the cores, and the latter is reused between the calls.
+func (self *Power) PartitionFunc(schedule *time.Schedule, ε float64,
+	fn func(float64, []float64)) {
+
+	power, schedule := self.prune(schedule)
+
+	ΔT, ssteps, fsteps := divide(schedule, ε, self.rtol)
+	ns := uint(len(ΔT))
+
+	events, offset := arrange(ssteps, fsteps, ns)
+
+	time := moments(schedule, ΔT)
+
+	row, busy := make([]float64, schedule.Cores), make([]int, schedule.Cores)
+	out := row
+	if self.static != nil || self.idle != nil {
+		out = make([]float64, schedule.Cores)
+	}
+
+	for k, l := uint(0), uint(0); k < ns; k++ {
+		m := offset[k]
+		apply(row, power, schedule, events[l:m])
+		occupy(busy, schedule, events[l:m])
+		l = m
+		if self.static != nil || self.idle != nil {
+			self.overlay(out, row, busy, time[k], true)
+		}
+		fn(ΔT[k], out)
+	}
+}
+
+// PartitionFuncEnergy is the same as PartitionFunc except that it also
+// accumulates the total energy consumption while streaming the time steps. The
+// static and idle energy consumed outside the steps is accounted for as well so
+// that the result is the same as the one of Energy up to the merging done with
+// respect to ε.
+func (self *Power) PartitionFuncEnergy(schedule *time.Schedule, ε float64,
+	fn func(float64, []float64)) float64 {
+
+	energy, duration := 0.0, 0.0
+	self.PartitionFunc(schedule, ε, func(Δt float64, row []float64) {
+		energy += sum(row) * Δt
+		duration += Δt
+		fn(Δt, row)
+	})
+	_, pruned := self.prune(schedule)
+
+	return energy + self.outside(pruned, duration)
+}
+
+// SampleFunc computes a power profile with respect to a sampling interval Δt
+// but passes each sample to a function instead of storing it. The function
+// receives the index of the sample and the power consumption of the cores, and
+// the latter is reused between the calls. The samples are the same as the ones
+// of Sample, and the output is the number of samples covered by the schedule.
+func (self *Power) SampleFunc(schedule *time.Schedule, Δt float64, ns uint,
+	fn func(uint, []float64)) uint {
+
+	power, schedule := self.prune(schedule)
+	return self.stream(power, schedule, Δt, ns, fn)
+}
+
+// SampleReduce computes a power profile with respect to a sampling interval Δt
+// and reduces the power consumption of the cores at each sample to a single
+// value. The samples are the same as the ones of Sample, and the full profile
+// is not constructed.
+func (self *Power) SampleReduce(schedule *time.Schedule, Δt float64, ns uint,
+	reduce func([]float64) float64) []float64 {
+
+	result := make([]float64, ns)
+	power, schedule := self.prune(schedule)
+	self.stream(power, schedule, Δt, ns, func(k uint, row []float64) {
+		result[k] = reduce(row)
+	})
+	return result
+}
+
+// SamplePeakStream computes a power profile with respect to a sampling interval
+// Δt and passes each sample to a function as SampleReduce does while tracking
+// the peak total power consumption of all cores. The outputs are the peak and
+// the index of the first sample attaining it. The peak is limited to the
+// sampling grid; Peak should be used for the exact value.
+func (self *Power) SamplePeakStream(schedule *time.Schedule, Δt float64, ns uint,
+	fn func(uint, []float64)) (float64, uint) {
+
+	peak, index := math.Inf(-1), uint(0)
+	power, schedule := self.prune(schedule)
+	self.stream(power, schedule, Δt, ns, func(k uint, row []float64) {
+		if total := sum(row); total > peak {
+			peak, index = total, k
+		}
+		fn(k, row)
+	})
+	if ns == 0 {
+		return 0, 0
+	}
+
+	return peak, index
+}
+
+// ReduceSum returns the total power consumption of the cores.
+func ReduceSum(row []float64) float64 {
+	return sum(row)
+}
+
+// ReduceMax returns the maximal power consumption of the cores.
+func ReduceMax(row []float64) float64 {
+	max := math.Inf(-1)
+	for _, x := range row {
+		max = math.Max(max, x)
+	}
+	return max
+}
+
+// stream computes the samples of Sample one at a time and passes each of them
+// to a function. The row is reused between the calls. The output is the number
+// of samples covered by the schedule.
+func (self *Power) stream(power []float64, schedule *time.Schedule, Δt float64, ns uint,
+	fn func(uint, []float64)) uint {
+
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	count := ns
+	if c := uint(schedule.Span / Δt); c < count {
+		count = c
+	}
+
+	ssteps, fsteps := make([]uint, nt), make([]uint, nt)
+	for i := uint(0); i < nt; i++ {
+		s, f := grid(schedule, i, Δt, count)
+		if s >= f {
+			s, f = count, count
+		}
+		ssteps[i], fsteps[i] = s, f
+	}
+
+	events, offset := arrange(ssteps, fsteps, count)
+
+	row, busy := make([]float64, nc), make([]int, nc)
+	out := row
+	if self.static != nil || self.idle != nil {
+		out = make([]float64, nc)
+	}
+
+	for k, l := uint(0), uint(0); k < ns; k++ {
+		if k <= count {
+			m := offset[k]
+			apply(row, power, schedule, events[l:m])
+			occupy(busy, schedule, events[l:m])
+			l = m
+		}
+		if self.static != nil || self.idle != nil {
+			self.overlay(out, row, busy, float64(k)*Δt, k < count)
+		}
+		fn(k, out)
+	}
+
+	return count
+}
+
+// overlay copies the power consumption of the cores and, if requested, adds the
+// static power consumption and the idle power consumption of the cores that
+// are not busy at a time moment. The number of tasks running on each core is
+// given by busy, which is ignored if no idle power is set.
+func (self *Power) overlay(out, row []float64, busy []int, time float64, covered bool) {
+	copy(out, row)
+	if !covered {
+		return
+	}
+	for j := range out {
+		if self.static != nil {
+			out[j] += self.static[j]
+		}
+		if self.idle != nil && busy[j] == 0 {
+			out[j] += self.idle(uint(j), time)
+		}
+	}
+}
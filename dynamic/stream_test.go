@@ -0,0 +1,184 @@
+package dynamic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestPartitionFunc(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+
+	test := func() {
+		P1, ΔT1 := power.Partition(schedule, ε)
+
+		P2, ΔT2 := []float64(nil), []float64(nil)
+		power.PartitionFunc(schedule, ε, func(Δt float64, row []float64) {
+			P2 = append(P2, row...)
+			ΔT2 = append(ΔT2, Δt)
+		})
+
+		assert.Close(P2, P1, 1e-14, t)
+		assert.Equal(ΔT2, ΔT1, t)
+	}
+
+	test()
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	test()
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}),
+		WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	test()
+}
+
+func TestPartitionFuncEnergy(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepare("002_040")
+
+	test := func() {
+		count := 0
+		energy := power.PartitionFuncEnergy(schedule, ε, func(float64, []float64) {
+			count++
+		})
+
+		_, ΔT := power.Partition(schedule, ε)
+		assert.Equal(count, len(ΔT), t)
+		assert.Close(energy, power.Energy(schedule), 1e-12, t)
+	}
+
+	test()
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	test()
+}
+
+func TestSampleFunc(t *testing.T) {
+	const (
+		Δt = 1e-3
+	)
+
+	power, schedule := prepare("002_040")
+
+	test := func(ns uint) {
+		P1, count1 := power.Sample(schedule, Δt, ns)
+
+		P2 := []float64(nil)
+		count2 := power.SampleFunc(schedule, Δt, ns, func(k uint, row []float64) {
+			assert.Equal(k, uint(len(P2))/schedule.Cores, t)
+			P2 = append(P2, row...)
+		})
+
+		assert.Close(P2, P1, 1e-14, t)
+		assert.Equal(count2, count1, t)
+	}
+
+	test(440)
+	test(500)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	test(500)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}),
+		WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	test(440)
+	test(500)
+}
+
+func TestSampleReduce(t *testing.T) {
+	const (
+		Δt = 1e-3
+	)
+
+	power, schedule := prepare("002_040")
+	nc := schedule.Cores
+
+	test := func(ns uint) {
+		P, _ := power.Sample(schedule, Δt, ns)
+
+		sums, maxs := make([]float64, ns), make([]float64, ns)
+		for i := uint(0); i < ns; i++ {
+			sums[i] = ReduceSum(P[i*nc : (i+1)*nc])
+			maxs[i] = ReduceMax(P[i*nc : (i+1)*nc])
+		}
+
+		assert.Equal(power.SampleReduce(schedule, Δt, ns, ReduceSum), sums, t)
+		assert.Equal(power.SampleReduce(schedule, Δt, ns, ReduceMax), maxs, t)
+	}
+
+	test(440)
+	test(500)
+	test(42)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	test(440)
+	test(500)
+	test(42)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}),
+		WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	test(440)
+	test(500)
+	test(42)
+
+	power, schedule = prepareRandom(8, 300)
+	nc = schedule.Cores
+
+	test(uint(schedule.Span / Δt))
+}
+
+func TestSamplePeakStream(t *testing.T) {
+	const (
+		Δt = 1e-3
+		ns = 500
+	)
+
+	power, schedule := prepare("002_040")
+	nc := schedule.Cores
+
+	test := func() {
+		P, _ := power.Sample(schedule, Δt, ns)
+
+		count := uint(0)
+		peak, index := power.SamplePeakStream(schedule, Δt, ns, func(k uint, row []float64) {
+			assert.Equal(row, P[k*nc:(k+1)*nc], t)
+			count++
+		})
+		assert.Equal(count, uint(ns), t)
+
+		expected, at := math.Inf(-1), uint(0)
+		for k := uint(0); k < ns; k++ {
+			if total := sum(P[k*nc : (k+1)*nc]); total > expected {
+				expected, at = total, k
+			}
+		}
+		assert.Equal(peak, expected, t)
+		assert.Equal(index, at, t)
+	}
+
+	test()
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	test()
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}),
+		WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	test()
+}
@@ -0,0 +1,72 @@
+package dynamic
+
+import (
+	"github.com/turing-complete/time"
+)
+
+// Summary is a summary of the power consumption of a schedule.
+type Summary struct {
+	Profile       *Profile  // The sampled power profile.
+	EnergyPerCore []float64 // The energy consumption of each core.
+	Energy        float64   // The total energy consumption.
+	Peak          float64   // The maximal total power consumption.
+}
+
+// Summary computes a sampled power profile, the energy consumption, and the
+// peak power consumption of a schedule. The power consumption of the tasks is
+// distributed only once.
+func (self *Power) Summary(schedule *time.Schedule, Δt float64, ns uint) Summary {
+	nc := schedule.Cores
+
+	power, schedule := self.prune(schedule)
+
+	P := make([]float64, nc*ns)
+	self.sampleInto(schedule, power, Δt, ns, P)
+
+	energy, static := self.breakdown(power, schedule)
+	for j := range energy {
+		energy[j] += static[j]
+	}
+
+	return Summary{
+		Profile:       uniform(P, nc, Δt),
+		EnergyPerCore: energy,
+		Energy:        sum(energy),
+		Peak:          self.peak(power, schedule),
+	}
+}
+
+// Comparison is a comparison of the power consumption of two schedules. Each
+// field is the difference between the second schedule and the first one;
+// hence, negative values indicate an improvement.
+type Comparison struct {
+	Energy        float64   // The difference in the total energy consumption.
+	Peak          float64   // The difference in the peak power consumption.
+	EnergyPerCore []float64 // The difference in the energy consumption of each core.
+}
+
+// ComparePower compares the power consumption of a base schedule with the one
+// of an optimized schedule.
+func ComparePower(base, opt *Power, baseSchedule, optSchedule *time.Schedule) Comparison {
+	baseEnergy := base.EnergyPerCore(baseSchedule)
+	optEnergy := opt.EnergyPerCore(optSchedule)
+
+	nc := len(baseEnergy)
+	if len(optEnergy) > nc {
+		nc = len(optEnergy)
+	}
+
+	Δ := make([]float64, nc)
+	for j := range baseEnergy {
+		Δ[j] -= baseEnergy[j]
+	}
+	for j := range optEnergy {
+		Δ[j] += optEnergy[j]
+	}
+
+	return Comparison{
+		Energy:        sum(optEnergy) - sum(baseEnergy),
+		Peak:          opt.Peak(optSchedule) - base.Peak(baseSchedule),
+		EnergyPerCore: Δ,
+	}
+}
@@ -0,0 +1,50 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestSummary(t *testing.T) {
+	const (
+		Δt = 1e-3
+	)
+
+	power, schedule := prepare("002_040")
+	power = New(power.platform, power.application, WithStaticPower([]float64{0.5, 0.25}))
+
+	summary := power.Summary(schedule, Δt, 440)
+
+	assert.Equal(summary.Profile.Equal(power.SampleProfile(schedule, Δt, 440)), true, t)
+	assert.Equal(summary.EnergyPerCore, power.EnergyPerCore(schedule), t)
+	assert.Equal(summary.Energy, power.Energy(schedule), t)
+	assert.Equal(summary.Peak, power.Peak(schedule), t)
+
+	power = New(power.platform, power.application, WithConstantIdlePower([]float64{0.5, 0.25}))
+
+	summary = power.Summary(schedule, Δt, 440)
+
+	assert.Equal(summary.Profile.Equal(power.SampleProfile(schedule, Δt, 440)), true, t)
+	assert.Equal(summary.EnergyPerCore, power.EnergyPerCore(schedule), t)
+	assert.Equal(summary.Peak, power.Peak(schedule), t)
+}
+
+func TestComparePower(t *testing.T) {
+	power, schedule := prepare("002_040")
+
+	comparison := ComparePower(power, power, schedule, schedule)
+	assert.Equal(comparison, Comparison{EnergyPerCore: []float64{0, 0}}, t)
+
+	base, baseSchedule := prepareCustom([][]float64{{2}, {3}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{0, 0}, []float64{2, 2})
+	opt, optSchedule := prepareCustom([][]float64{{2}, {3}}, []uint{0, 0},
+		[]uint{0, 1}, []float64{0, 2}, []float64{2, 3})
+
+	comparison = ComparePower(base, opt, baseSchedule, optSchedule)
+	assert.Equal(comparison, Comparison{
+		Energy:        -3,
+		Peak:          -2,
+		EnergyPerCore: []float64{0, -3},
+	}, t)
+}
@@ -0,0 +1,46 @@
+package dynamic
+
+import (
+	"fmt"
+)
+
+// PowerEntry is a measurement of the power consumption of a task type on a
+// core.
+type PowerEntry struct {
+	Core  uint
+	Type  uint
+	Power float64
+}
+
+// BuildPowerTable assembles a table of power consumption indexed first by core
+// and then by task type, which is the layout expected by system.Core.Power.
+// Each pair of a core and a task type should be measured exactly once.
+func BuildPowerTable(entries []PowerEntry, cores, types uint) ([][]float64, error) {
+	table := make([][]float64, cores)
+	found := make([][]bool, cores)
+	for j := uint(0); j < cores; j++ {
+		table[j] = make([]float64, types)
+		found[j] = make([]bool, types)
+	}
+
+	for _, entry := range entries {
+		j, k := entry.Core, entry.Type
+		if j >= cores || k >= types {
+			return nil, fmt.Errorf("the entry for core %d and type %d is out of range", j, k)
+		}
+		if found[j][k] {
+			return nil, fmt.Errorf("the entry for core %d and type %d is duplicated", j, k)
+		}
+		table[j][k], found[j][k] = entry.Power, true
+	}
+
+	for j := uint(0); j < cores; j++ {
+		for k := uint(0); k < types; k++ {
+			if !found[j][k] {
+				return nil, fmt.Errorf("the entry for core %d and type %d is missing", j, k)
+			}
+		}
+	}
+
+	return table, nil
+}
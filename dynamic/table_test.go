@@ -0,0 +1,29 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestBuildPowerTable(t *testing.T) {
+	entries := []PowerEntry{
+		{Core: 1, Type: 0, Power: 3},
+		{Core: 0, Type: 1, Power: 2},
+		{Core: 0, Type: 0, Power: 1},
+		{Core: 1, Type: 1, Power: 4},
+	}
+
+	table, err := BuildPowerTable(entries, 2, 2)
+	assert.Success(err, t)
+	assert.Equal(table, [][]float64{{1, 2}, {3, 4}}, t)
+
+	_, err = BuildPowerTable(entries[:3], 2, 2)
+	assert.Failure(err, t)
+
+	_, err = BuildPowerTable(append(entries, entries[0]), 2, 2)
+	assert.Failure(err, t)
+
+	_, err = BuildPowerTable(entries, 2, 1)
+	assert.Failure(err, t)
+}
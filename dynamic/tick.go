@@ -0,0 +1,48 @@
+package dynamic
+
+// TickSchedule is a schedule whose time is measured in integer clock ticks.
+type TickSchedule struct {
+	Cores   uint
+	Tasks   uint
+	Mapping []uint
+	Start   []uint
+	Finish  []uint
+}
+
+// SampleTicks computes a power profile of a schedule given in integer clock
+// ticks with a sampling interval of one tick. Since the start and finish times
+// are exact sample indices, no rounding takes place.
+//
+// The required number of samples is specified by ns; short schedules are
+// extended while long ones are truncated. The second output is the number of
+// samples that are covered by the schedule, which is at most ns.
+func (self *Power) SampleTicks(schedule *TickSchedule, ns uint) ([]float64, uint) {
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	P := make([]float64, nc*ns)
+
+	span := uint(0)
+	for i := uint(0); i < nt; i++ {
+		j := schedule.Mapping[i]
+		p := self.lookup(j, i)
+
+		s, f := schedule.Start[i], schedule.Finish[i]
+		if f > span {
+			span = f
+		}
+		if f > ns {
+			f = ns
+		}
+
+		for ; s < f; s++ {
+			P[s*nc+j] += p
+		}
+	}
+
+	if span < ns {
+		ns = span
+	}
+	self.superimpose(P, nc, ns)
+
+	return P, ns
+}
@@ -0,0 +1,27 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestSampleTicks(t *testing.T) {
+	power, _ := prepareCustom([][]float64{{1, 2, 3}}, []uint{0, 1, 2}, nil, nil, nil)
+
+	schedule := &TickSchedule{
+		Cores:   1,
+		Tasks:   3,
+		Mapping: []uint{0, 0, 0},
+		Start:   []uint{0, 3, 4},
+		Finish:  []uint{3, 4, 7},
+	}
+
+	P, count := power.SampleTicks(schedule, 8)
+	assert.Equal(P, []float64{1, 1, 1, 2, 3, 3, 3, 0}, t)
+	assert.Equal(count, uint(7), t)
+
+	P, count = power.SampleTicks(schedule, 5)
+	assert.Equal(P, []float64{1, 1, 1, 2, 3}, t)
+	assert.Equal(count, uint(5), t)
+}
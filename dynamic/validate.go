@@ -0,0 +1,170 @@
+package dynamic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/turing-complete/time"
+)
+
+// Validate checks if a schedule is consistent with the platform and
+// application of the power calculator.
+func (self *Power) Validate(schedule *time.Schedule) error {
+	nc, nt := uint(len(self.platform.Cores)), self.application.Len()
+
+	if schedule.Cores != nc {
+		return fmt.Errorf("the schedule has %d cores while the platform has %d",
+			schedule.Cores, nc)
+	}
+	if schedule.Tasks != nt {
+		return fmt.Errorf("the schedule has %d tasks while the application has %d",
+			schedule.Tasks, nt)
+	}
+	if uint(len(schedule.Mapping)) != nt || uint(len(schedule.Start)) != nt ||
+		uint(len(schedule.Finish)) != nt {
+
+		return errors.New("the mapping, start times, and finish times should have one entry per task")
+	}
+	for i, j := range schedule.Mapping {
+		if j >= nc {
+			return fmt.Errorf("task %d is mapped onto nonexistent core %d", i, j)
+		}
+	}
+	for i := uint(0); i < nt; i++ {
+		if s := schedule.Start[i]; math.IsNaN(s) || math.IsInf(s, 0) {
+			return fmt.Errorf("task %d has a non-finite start time %g", i, s)
+		}
+		if f := schedule.Finish[i]; math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("task %d has a non-finite finish time %g", i, f)
+		}
+	}
+	if self.static != nil && uint(len(self.static)) != nc {
+		return fmt.Errorf("the static power is given for %d cores while the platform has %d",
+			len(self.static), nc)
+	}
+	if self.scaling != nil && uint(len(self.scaling)) != nt {
+		return fmt.Errorf("the operating points are given for %d tasks while the application has %d",
+			len(self.scaling), nt)
+	}
+	if self.activity != nil && uint(len(self.activity)) != nt {
+		return fmt.Errorf("the activity factor is given for %d tasks while the application has %d",
+			len(self.activity), nt)
+	}
+	if self.variance != nil {
+		if uint(len(self.variance)) != nt {
+			return fmt.Errorf("the variance is given for %d tasks while the application has %d",
+				len(self.variance), nt)
+		}
+		for i, v := range self.variance {
+			if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+				return fmt.Errorf("task %d has an invalid variance %g", i, v)
+			}
+		}
+	}
+	if self.phases != nil && uint(len(self.phases)) != nt {
+		return fmt.Errorf("the phases are given for %d tasks while the application has %d",
+			len(self.phases), nt)
+	}
+
+	return nil
+}
+
+// ValidateWeights checks if the weights of PartitionWeighted and SampleWeighted
+// are consistent with a schedule: there should be one entry per task, the cores
+// should exist, the fractions should be finite and nonnegative, and the
+// fractions of each task should sum up to one within an absolute tolerance of
+// 1e-9. Nil weights are valid.
+func (self *Power) ValidateWeights(schedule *time.Schedule, weights []map[uint]float64) error {
+	const (
+		tolerance = 1e-9
+	)
+
+	if weights == nil {
+		return nil
+	}
+
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	if uint(len(weights)) != nt {
+		return fmt.Errorf("the weights are given for %d tasks while the schedule has %d",
+			len(weights), nt)
+	}
+	for i := range weights {
+		if weights[i] == nil {
+			continue
+		}
+		total := 0.0
+		for j, w := range weights[i] {
+			if j >= nc {
+				return fmt.Errorf("task %d is weighted onto nonexistent core %d", i, j)
+			}
+			if math.IsNaN(w) || math.IsInf(w, 0) || w < 0 {
+				return fmt.Errorf("task %d has an invalid weight %g on core %d", i, w, j)
+			}
+			total += w
+		}
+		if math.Abs(total-1) > tolerance {
+			return fmt.Errorf("the weights of task %d sum up to %g instead of one", i, total)
+		}
+	}
+
+	return nil
+}
+
+// ValidateFragments checks if the fragments of PartitionFragmented and
+// SampleFragmented are consistent with a schedule: there should be one entry
+// per task, the cores should exist, and each fragment should have finite start
+// and finish times with the former not exceeding the latter. Nil fragments are
+// valid.
+func (self *Power) ValidateFragments(schedule *time.Schedule, fragments [][]Fragment) error {
+	if fragments == nil {
+		return nil
+	}
+
+	nc, nt := schedule.Cores, schedule.Tasks
+
+	if uint(len(fragments)) != nt {
+		return fmt.Errorf("the fragments are given for %d tasks while the schedule has %d",
+			len(fragments), nt)
+	}
+	for i := range fragments {
+		for k, fragment := range fragments[i] {
+			if fragment.Core >= nc {
+				return fmt.Errorf("fragment %d of task %d is mapped onto nonexistent core %d",
+					k, i, fragment.Core)
+			}
+			s, f := fragment.Start, fragment.Finish
+			if math.IsNaN(s) || math.IsInf(s, 0) || math.IsNaN(f) || math.IsInf(f, 0) {
+				return fmt.Errorf("fragment %d of task %d has non-finite times", k, i)
+			}
+			if s > f {
+				return fmt.Errorf("fragment %d of task %d starts at %g after finishing at %g",
+					k, i, s, f)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateGating checks if a power-gating policy is consistent with a schedule:
+// the threshold should be finite and nonnegative, and the sleep power and
+// wake-up energy should be given for each core.
+func (self *Power) ValidateGating(schedule *time.Schedule, gating *Gating) error {
+	nc := schedule.Cores
+
+	if t := gating.Threshold; math.IsNaN(t) || math.IsInf(t, 0) || t < 0 {
+		return fmt.Errorf("the gating threshold %g is invalid", t)
+	}
+	if uint(len(gating.Sleep)) != nc {
+		return fmt.Errorf("the sleep power is given for %d cores while the schedule has %d",
+			len(gating.Sleep), nc)
+	}
+	if uint(len(gating.Wakeup)) != nc {
+		return fmt.Errorf("the wake-up energy is given for %d cores while the schedule has %d",
+			len(gating.Wakeup), nc)
+	}
+
+	return nil
+}
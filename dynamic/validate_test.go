@@ -0,0 +1,114 @@
+package dynamic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestValidate(t *testing.T) {
+	power, schedule := prepare("002_040")
+	assert.Success(power.Validate(schedule), t)
+
+	invalid := *schedule
+	invalid.Cores = 3
+	assert.Failure(power.Validate(&invalid), t)
+
+	invalid = *schedule
+	invalid.Tasks = 39
+	assert.Failure(power.Validate(&invalid), t)
+
+	invalid = *schedule
+	invalid.Start = invalid.Start[:39]
+	assert.Failure(power.Validate(&invalid), t)
+
+	invalid = *schedule
+	invalid.Mapping = append([]uint{2}, schedule.Mapping[1:]...)
+	assert.Failure(power.Validate(&invalid), t)
+
+	invalid = *schedule
+	invalid.Finish = append([]float64(nil), schedule.Finish...)
+	invalid.Finish[7] = math.Inf(1)
+	err := power.Validate(&invalid)
+	assert.Failure(err, t)
+	assert.Equal(err.Error(), "task 7 has a non-finite finish time +Inf", t)
+
+	invalid = *schedule
+	invalid.Start = append([]float64(nil), schedule.Start...)
+	invalid.Start[3] = math.NaN()
+	assert.Failure(power.Validate(&invalid), t)
+
+	power = New(power.platform, power.application, WithStaticPower([]float64{1}))
+	assert.Failure(power.Validate(schedule), t)
+
+	power = New(power.platform, power.application, WithActivity([]float64{1}))
+	assert.Failure(power.Validate(schedule), t)
+
+	power = New(power.platform, power.application, WithPowerVariance([]float64{1}))
+	assert.Failure(power.Validate(schedule), t)
+
+	variance := make([]float64, schedule.Tasks)
+	power = New(power.platform, power.application, WithPowerVariance(variance))
+	assert.Success(power.Validate(schedule), t)
+
+	variance[5] = -1
+	assert.Failure(power.Validate(schedule), t)
+}
+
+func TestValidateWeights(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 6}, {4, 8}},
+		[]uint{0, 1}, []uint{0, 1},
+		[]float64{0, 1}, []float64{2, 3})
+
+	assert.Success(power.ValidateWeights(schedule, nil), t)
+	assert.Success(power.ValidateWeights(schedule, []map[uint]float64{{0: 0.25, 1: 0.75}, nil}), t)
+
+	assert.Failure(power.ValidateWeights(schedule, []map[uint]float64{nil}), t)
+	assert.Failure(power.ValidateWeights(schedule, []map[uint]float64{{2: 1}, nil}), t)
+	assert.Failure(power.ValidateWeights(schedule, []map[uint]float64{{0: 1.5, 1: -0.5}, nil}), t)
+
+	err := power.ValidateWeights(schedule, []map[uint]float64{nil, {0: 0.5, 1: 0.25}})
+	assert.Failure(err, t)
+	assert.Equal(err.Error(), "the weights of task 1 sum up to 0.75 instead of one", t)
+}
+
+func TestValidateFragments(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2, 6}, {4, 8}},
+		[]uint{0, 1}, []uint{0, 1},
+		[]float64{0, 1}, []float64{3, 3})
+
+	assert.Success(power.ValidateFragments(schedule, nil), t)
+	assert.Success(power.ValidateFragments(schedule,
+		[][]Fragment{{{Core: 0, Start: 0, Finish: 1}, {Core: 1, Start: 2, Finish: 3}}, nil}), t)
+
+	assert.Failure(power.ValidateFragments(schedule, [][]Fragment{nil}), t)
+	assert.Failure(power.ValidateFragments(schedule,
+		[][]Fragment{{{Core: 2, Start: 0, Finish: 1}}, nil}), t)
+	assert.Failure(power.ValidateFragments(schedule,
+		[][]Fragment{{{Core: 0, Start: 0, Finish: math.Inf(1)}}, nil}), t)
+
+	err := power.ValidateFragments(schedule, [][]Fragment{nil, {{Core: 1, Start: 2, Finish: 1}}})
+	assert.Failure(err, t)
+	assert.Equal(err.Error(), "fragment 0 of task 1 starts at 2 after finishing at 1", t)
+}
+
+func TestValidateGating(t *testing.T) {
+	power, schedule := prepareCustom([][]float64{{2}, {3}},
+		[]uint{0, 0, 0}, []uint{0, 0, 1},
+		[]float64{0, 3, 0}, []float64{1, 4, 1})
+
+	gating := &Gating{Threshold: 1.5, Sleep: []float64{0.25, 0.5}, Wakeup: []float64{2, 3}}
+	assert.Success(power.ValidateGating(schedule, gating), t)
+
+	gating.Wakeup = gating.Wakeup[:1]
+	assert.Failure(power.ValidateGating(schedule, gating), t)
+
+	gating = &Gating{Threshold: -1, Sleep: []float64{0.25, 0.5}, Wakeup: []float64{2, 3}}
+	assert.Failure(power.ValidateGating(schedule, gating), t)
+
+	gating = &Gating{Threshold: 1, Sleep: []float64{0.25}, Wakeup: []float64{2, 3}}
+	err := power.ValidateGating(schedule, gating)
+	assert.Failure(err, t)
+	assert.Equal(err.Error(), "the sleep power is given for 1 cores while the schedule has 2", t)
+}
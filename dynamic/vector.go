@@ -0,0 +1,31 @@
+package dynamic
+
+import (
+	"math"
+
+	"github.com/turing-complete/time"
+)
+
+// PowerVector is the power consumption of the tasks of an application indexed
+// by task.
+type PowerVector []float64
+
+// TotalEnergy returns the total energy consumed by the tasks of a schedule.
+func (self PowerVector) TotalEnergy(schedule *time.Schedule) float64 {
+	return sum(self.PerCore(schedule))
+}
+
+// PerCore returns the energy consumed by the tasks of a schedule on each core.
+// The static power consumption is not taken into account.
+func (self PowerVector) PerCore(schedule *time.Schedule) []float64 {
+	return window(self, schedule, math.Inf(-1), math.Inf(1))
+}
+
+// Scale returns a copy of the vector with each element multiplied by a factor.
+func (self PowerVector) Scale(factor float64) PowerVector {
+	result := make(PowerVector, len(self))
+	for i, p := range self {
+		result[i] = factor * p
+	}
+	return result
+}
@@ -0,0 +1,19 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestPowerVector(t *testing.T) {
+	power, schedule := prepare("002_040")
+
+	vector := power.Distribute(schedule)
+	assert.Close(vector.PerCore(schedule), power.EnergyPerCore(schedule), 1e-12, t)
+	assert.Close(vector.TotalEnergy(schedule), power.Energy(schedule), 1e-12, t)
+
+	scaled := vector.Scale(2)
+	assert.Close(scaled.TotalEnergy(schedule), 2*power.Energy(schedule), 1e-12, t)
+	assert.Equal(scaled[0], 2*vector[0], t)
+}
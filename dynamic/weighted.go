@@ -0,0 +1,87 @@
+package dynamic
+
+import (
+	"sort"
+
+	"github.com/turing-complete/time"
+)
+
+// PartitionWeighted is the same as Partition except that tasks can be split
+// across several cores. The cores of task i and the fractions of its power
+// consumption drawn on those cores are given by weights[i], and the fractions
+// of each task should sum up to one, which can be checked using
+// ValidateWeights. If weights or weights[i] is nil or missing, the task is
+// assumed to run entirely on the core given by the mapping of the schedule. The
+// power consumption of the tasks that share a core at the same time is summed
+// up, and a core is considered idle only when no fraction of any task is drawn
+// on it.
+func (self *Power) PartitionWeighted(schedule *time.Schedule, weights []map[uint]float64,
+	ε float64) ([]float64, []float64) {
+
+	power, schedule := self.exclude(self.expand(schedule, weights))
+	return self.partitionInto(power, schedule, ε, nil, nil)
+}
+
+// SampleWeighted is the same as Sample except that tasks can be split across
+// several cores as described in PartitionWeighted.
+func (self *Power) SampleWeighted(schedule *time.Schedule, weights []map[uint]float64,
+	Δt float64, ns uint) ([]float64, uint) {
+
+	P := make([]float64, schedule.Cores*ns)
+	power, schedule := self.exclude(self.expand(schedule, weights))
+	return P, self.sampleInto(schedule, power, Δt, ns, P)
+}
+
+// expand splits the tasks into their phases, if any, replaces each task that is
+// split across several cores with one task per core, and distributes the power
+// consumption of the resulting tasks.
+func (self *Power) expand(schedule *time.Schedule,
+	weights []map[uint]float64) ([]float64, *time.Schedule) {
+
+	if weights == nil {
+		return self.split(self.Distribute(schedule), schedule)
+	}
+
+	phased, owners, factors := schedule, []uint(nil), []float64(nil)
+	if self.phases != nil {
+		phased, owners, factors = self.phase(schedule)
+	}
+
+	nt := phased.Tasks
+
+	result := *phased
+	result.Tasks = 0
+	result.Mapping = make([]uint, 0, nt)
+	result.Start = make([]float64, 0, nt)
+	result.Finish = make([]float64, 0, nt)
+
+	power := make([]float64, 0, nt)
+	add := func(k, i, j uint, fraction float64) {
+		result.Tasks++
+		result.Mapping = append(result.Mapping, j)
+		result.Start = append(result.Start, phased.Start[k])
+		result.Finish = append(result.Finish, phased.Finish[k])
+		power = append(power, fraction*self.lookup(j, i))
+	}
+
+	for k := uint(0); k < nt; k++ {
+		i, factor := k, 1.0
+		if owners != nil {
+			i, factor = owners[k], factors[k]
+		}
+		if i >= uint(len(weights)) || weights[i] == nil {
+			add(k, i, phased.Mapping[k], factor)
+			continue
+		}
+		cores := make([]uint, 0, len(weights[i]))
+		for j := range weights[i] {
+			cores = append(cores, j)
+		}
+		sort.Slice(cores, func(a, b int) bool { return cores[a] < cores[b] })
+		for _, j := range cores {
+			add(k, i, j, factor*weights[i][j])
+		}
+	}
+
+	return power, &result
+}
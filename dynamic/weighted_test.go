@@ -0,0 +1,65 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestPartitionWeighted(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2, 6}, {4, 8}},
+		[]uint{0, 1}, []uint{0, 1},
+		[]float64{0, 1}, []float64{2, 3})
+
+	P, ΔT := power.PartitionWeighted(schedule, nil, ε)
+	assert.Equal(P, []float64{2, 0, 2, 8, 0, 8}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	weights := []map[uint]float64{{0: 0.5, 1: 0.5}, nil}
+
+	P, ΔT = power.PartitionWeighted(schedule, weights, ε)
+	assert.Equal(P, []float64{1, 2, 1, 10, 0, 8}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	P, ΔT = power.PartitionWeighted(schedule, weights[:1], ε)
+	assert.Equal(P, []float64{1, 2, 1, 10, 0, 8}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	P, count := power.SampleWeighted(schedule, weights, 0.5, 6)
+	assert.Equal(P, []float64{1, 2, 1, 2, 1, 10, 1, 10, 0, 8, 0, 8}, t)
+	assert.Equal(count, uint(6), t)
+}
+
+func TestPartitionWeightedIdle(t *testing.T) {
+	const (
+		ε = 1e-14
+	)
+
+	power, schedule := prepareCustom([][]float64{{2, 6}, {4, 8}, {1, 1}},
+		[]uint{0, 1}, []uint{0, 1},
+		[]float64{0, 1}, []float64{2, 3})
+	power = New(power.platform, power.application,
+		WithStaticPower([]float64{1, 1, 1}),
+		WithIdlePower(func(uint, float64) float64 { return 0.5 }))
+
+	weights := []map[uint]float64{{0: 0.5, 1: 0.5}, nil}
+
+	P, ΔT := power.PartitionWeighted(schedule, weights, ε)
+	assert.Equal(P, []float64{2, 3, 1.5, 2, 11, 1.5, 1.5, 9, 1.5}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+
+	P, count := power.SampleWeighted(schedule, weights, 1, 3)
+	assert.Equal(P, []float64{2, 3, 1.5, 2, 11, 1.5, 1.5, 9, 1.5}, t)
+	assert.Equal(count, uint(3), t)
+
+	phases := [][]Phase{{{Fraction: 0.5, Power: 2}, {Fraction: 0.5, Power: 0}}, nil}
+	power = New(power.platform, power.application, WithPhases(phases))
+
+	P, ΔT = power.PartitionWeighted(schedule, weights, ε)
+	assert.Equal(P, []float64{2, 4, 0, 0, 8, 0, 0, 8, 0}, t)
+	assert.Equal(ΔT, []float64{1, 1, 1}, t)
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/turing-complete/system"
+)
+
+// FromActivity returns a model wherein the power consumption of a task is
+// given by the switching activity α of its type and the effective capacitance
+// C, supply voltage V, and frequency f of the core as α·C·V²·f. The activity
+// factors are indexed by the types of the tasks, and the rest of the parameters
+// by the cores.
+func FromActivity(application *system.Application, activity, capacitance,
+	voltage, frequency []float64) (*Table, error) {
+
+	nc := len(capacitance)
+	if len(voltage) != nc || len(frequency) != nc {
+		return nil, errors.New("the core parameters should have the same length")
+	}
+
+	power := make([][]float64, nc)
+	for j := range power {
+		power[j] = make([]float64, len(activity))
+		for k, α := range activity {
+			power[j][k] = α * capacitance[j] * voltage[j] * voltage[j] * frequency[j]
+		}
+	}
+
+	return New(application, power)
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestFromActivity(t *testing.T) {
+	_, application, _ := prepare()
+
+	model, err := FromActivity(application, []float64{0.5, 1},
+		[]float64{1, 2}, []float64{1, 2}, []float64{2, 1})
+	assert.Success(err, t)
+	assert.Equal(model.Power(0, 0), 1.0, t)
+	assert.Equal(model.Power(0, 1), 2.0, t)
+	assert.Equal(model.Power(1, 0), 4.0, t)
+	assert.Equal(model.Power(1, 2), 8.0, t)
+
+	_, err = FromActivity(application, []float64{0.5, 1},
+		[]float64{1, 2}, []float64{1}, []float64{2, 1})
+	assert.Failure(err, t)
+
+	_, err = FromActivity(application, []float64{0.5},
+		[]float64{1}, []float64{1}, []float64{1})
+	assert.Failure(err, t)
+}
@@ -0,0 +1,34 @@
+// Package model provides models of the power consumption of tasks that can be
+// plugged into the dynamic package via dynamic.WithModel.
+package model
+
+import (
+	"fmt"
+
+	"github.com/turing-complete/system"
+)
+
+// Table is a model that looks up the power consumption of a task in a table
+// indexed by the cores and the types of the tasks.
+type Table struct {
+	application *system.Application
+	power       [][]float64
+}
+
+// New returns a model with the power consumption of the tasks of each type on
+// each core given by power[core][type].
+func New(application *system.Application, power [][]float64) (*Table, error) {
+	for j := range power {
+		for _, task := range application.Tasks {
+			if task.Type >= uint(len(power[j])) {
+				return nil, fmt.Errorf("core %d has no power value for type %d", j, task.Type)
+			}
+		}
+	}
+	return &Table{application: application, power: power}, nil
+}
+
+// Power returns the power consumption of a task on a core.
+func (self *Table) Power(core, task uint) float64 {
+	return self.power[core][self.application.Tasks[task].Type]
+}
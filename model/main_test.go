@@ -0,0 +1,41 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/power/dynamic"
+	"github.com/turing-complete/system"
+	"github.com/turing-complete/time"
+)
+
+func prepare() (*system.Platform, *system.Application, *time.Schedule) {
+	platform := &system.Platform{Cores: []system.Core{{Power: []float64{1, 1}}, {Power: []float64{1, 1}}}}
+	application := &system.Application{Tasks: []system.Task{{Type: 0}, {Type: 1}, {Type: 1}}}
+
+	schedule := &time.Schedule{
+		Cores:   2,
+		Tasks:   3,
+		Mapping: []uint{0, 1, 0},
+		Start:   []float64{0, 0, 1},
+		Finish:  []float64{1, 2, 2},
+		Span:    2,
+	}
+
+	return platform, application, schedule
+}
+
+func TestNew(t *testing.T) {
+	platform, application, schedule := prepare()
+
+	model, err := New(application, [][]float64{{1, 2}, {3, 4}})
+	assert.Success(err, t)
+	assert.Equal(model.Power(0, 0), 1.0, t)
+	assert.Equal(model.Power(1, 2), 4.0, t)
+
+	power := dynamic.New(platform, application, dynamic.WithModel(model))
+	assert.Equal(power.Distribute(schedule), dynamic.PowerVector{1, 4, 2}, t)
+
+	_, err = New(application, [][]float64{{1, 2}, {3}})
+	assert.Failure(err, t)
+}
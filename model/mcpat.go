@@ -0,0 +1,68 @@
+package model
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/turing-complete/system"
+)
+
+// FromMcPAT returns a model for a platform of nc identical cores based on the
+// reports of McPAT, one per task type. McPAT takes an XML description of the
+// architecture and the activity statistics of a workload and prints a textual
+// report; each report is expected to be produced with the statistics of the
+// corresponding task type. The runtime dynamic power of the core is extracted
+// from each report by ParseMcPAT. Heterogeneous platforms can be handled by
+// calling ParseMcPAT for each core and type and passing the result to New.
+func FromMcPAT(application *system.Application, nc uint,
+	reports []io.Reader) (*Table, error) {
+
+	values := make([]float64, len(reports))
+	for k, report := range reports {
+		value, err := ParseMcPAT(report)
+		if err != nil {
+			return nil, fmt.Errorf("report %d: %v", k, err)
+		}
+		values[k] = value
+	}
+
+	power := make([][]float64, nc)
+	for j := range power {
+		power[j] = append([]float64(nil), values...)
+	}
+
+	return New(application, power)
+}
+
+// ParseMcPAT extracts the runtime dynamic power of a core, in watts, from a
+// report of McPAT, which is the first Runtime Dynamic entry of the Core
+// section.
+func ParseMcPAT(r io.Reader) (float64, error) {
+	scanner := bufio.NewScanner(r)
+
+	core := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !core {
+			core = line == "Core:"
+			continue
+		}
+		if !strings.HasPrefix(line, "Runtime Dynamic") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 5 || fields[2] != "=" || fields[4] != "W" {
+			return 0, fmt.Errorf("the line %q is malformed", line)
+		}
+		return strconv.ParseFloat(fields[3], 64)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, errors.New("the report has no runtime dynamic power of a core")
+}
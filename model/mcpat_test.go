@@ -0,0 +1,62 @@
+package model
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+const report = `McPAT (version 1.3 of Feb, 2015) results
+*****************************************************************************************
+Processor:
+  Area = 52.2418 mm^2
+  Peak Power = 81.907 W
+  Runtime Dynamic = 31.6089 W
+
+  Total Cores: 4 cores
+    Runtime Dynamic = 23.7941 W
+
+*****************************************************************************************
+Core:
+      Area = 6.85534 mm^2
+      Peak Dynamic = 9.89376 W
+      Subthreshold Leakage = 2.38616 W
+      Gate Leakage = 0.564246 W
+      Runtime Dynamic = %s W
+
+      Instruction Fetch Unit:
+        Runtime Dynamic = 0.52 W
+`
+
+func TestParseMcPAT(t *testing.T) {
+	value, err := ParseMcPAT(strings.NewReader(strings.Replace(report, "%s", "5.94853", 1)))
+	assert.Success(err, t)
+	assert.Equal(value, 5.94853, t)
+
+	_, err = ParseMcPAT(strings.NewReader(strings.Replace(report, "%s", "x", 1)))
+	assert.Failure(err, t)
+
+	_, err = ParseMcPAT(strings.NewReader("Processor:\n  Runtime Dynamic = 1 W\n"))
+	assert.Failure(err, t)
+}
+
+func TestFromMcPAT(t *testing.T) {
+	_, application, _ := prepare()
+
+	reports := []io.Reader{
+		strings.NewReader(strings.Replace(report, "%s", "5", 1)),
+		strings.NewReader(strings.Replace(report, "%s", "7", 1)),
+	}
+	model, err := FromMcPAT(application, 2, reports)
+	assert.Success(err, t)
+	assert.Equal(model.Power(0, 0), 5.0, t)
+	assert.Equal(model.Power(1, 1), 7.0, t)
+
+	model.power[0][0] = 1
+	assert.Equal(model.Power(1, 0), 5.0, t)
+
+	_, err = FromMcPAT(application, 2, []io.Reader{strings.NewReader("")})
+	assert.Failure(err, t)
+}
@@ -0,0 +1,134 @@
+package static
+
+import (
+	"math"
+
+	"github.com/turing-complete/time"
+)
+
+// Model is an exponential model of the leakage power of a core type. The power
+// at temperature T and voltage V is
+//
+//	Nominal · (V / Voltage) · exp(Alpha · (T - Temperature) + Beta · (V - Voltage)),
+//
+// where Temperature and Voltage are the reference conditions at which the
+// leakage power is equal to Nominal.
+type Model struct {
+	Nominal     float64
+	Temperature float64
+	Voltage     float64
+	Alpha       float64
+	Beta        float64
+}
+
+// Compute returns the leakage power at a temperature and a voltage.
+func (self *Model) Compute(temperature, voltage float64) float64 {
+	return self.Nominal * (voltage / self.Voltage) *
+		math.Exp(self.Alpha*(temperature-self.Temperature)+self.Beta*(voltage-self.Voltage))
+}
+
+// Leakage is a calculator of the leakage power of the cores of a platform.
+type Leakage struct {
+	models      []Model
+	types       []uint
+	temperature []float64
+	voltage     []float64
+}
+
+// NewLeakage returns a calculator of the leakage power. The models are given
+// per core type, and the type of each core is given by types. The cores are
+// initially at the reference temperature and voltage of their models.
+func NewLeakage(models []Model, types []uint) *Leakage {
+	nc := len(types)
+
+	leakage := &Leakage{
+		models:      models,
+		types:       types,
+		temperature: make([]float64, nc),
+		voltage:     make([]float64, nc),
+	}
+	for j, k := range types {
+		leakage.temperature[j] = models[k].Temperature
+		leakage.voltage[j] = models[k].Voltage
+	}
+
+	return leakage
+}
+
+// SetTemperature sets the temperature of each core.
+func (self *Leakage) SetTemperature(temperature []float64) {
+	copy(self.temperature, temperature)
+}
+
+// SetVoltage sets the supply voltage of each core.
+func (self *Leakage) SetVoltage(voltage []float64) {
+	copy(self.voltage, voltage)
+}
+
+// Distribute returns the leakage power of the cores at the current temperature
+// and voltage.
+func (self *Leakage) Distribute() []float64 {
+	power := make([]float64, len(self.types))
+	for j, k := range self.types {
+		power[j] = self.models[k].Compute(self.temperature[j], self.voltage[j])
+	}
+	return power
+}
+
+// Correct sets the temperature of each core and adds the leakage power of the
+// cores at the temperature and the current voltage to power, which makes the
+// calculator usable as a corrector in a coupled power–thermal simulation.
+func (self *Leakage) Correct(temperature, power []float64) {
+	self.SetTemperature(temperature)
+	for j, k := range self.types {
+		power[j] += self.models[k].Compute(self.temperature[j], self.voltage[j])
+	}
+}
+
+// Partition computes a leakage power profile over the span of a schedule. Since
+// the leakage power does not depend on the tasks, the profile has a single time
+// step, which covers the whole span; ε is accepted for symmetry with the
+// dynamic package.
+func (self *Leakage) Partition(schedule *time.Schedule, ε float64) ([]float64, []float64) {
+	if schedule.Span <= 0 {
+		return nil, nil
+	}
+	return self.Distribute(), []float64{schedule.Span}
+}
+
+// Sample computes a leakage power profile with respect to a sampling interval
+// Δt. The samples are aligned with the ones of the dynamic package: the leakage
+// power is drawn in the samples covered by the schedule, and the second output
+// is the number of such samples, which is at most ns.
+func (self *Leakage) Sample(schedule *time.Schedule, Δt float64, ns uint) ([]float64, uint) {
+	nc := uint(len(self.types))
+
+	P := make([]float64, nc*ns)
+	if count := uint(schedule.Span / Δt); count < ns {
+		ns = count
+	}
+
+	power := self.Distribute()
+	for i := uint(0); i < ns; i++ {
+		copy(P[i*nc:(i+1)*nc], power)
+	}
+
+	return P, ns
+}
+
+// Progress returns a function for computing the leakage power at an arbitrary
+// time moment. The leakage power is drawn within [0, span] and is zero outside.
+// The temperature and voltage are the ones at the time of the call to
+// Progress.
+func (self *Leakage) Progress(schedule *time.Schedule) func(float64, []float64) {
+	power, span := self.Distribute(), schedule.Span
+	return func(time float64, result []float64) {
+		if 0 <= time && time <= span {
+			copy(result, power)
+		} else {
+			for j := range result {
+				result[j] = 0
+			}
+		}
+	}
+}
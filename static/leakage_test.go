@@ -0,0 +1,66 @@
+package static
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/time"
+)
+
+func prepareLeakage() *Leakage {
+	models := []Model{
+		{Nominal: 1, Temperature: 318.15, Voltage: 1, Alpha: 0.02, Beta: 2},
+		{Nominal: 0.5, Temperature: 318.15, Voltage: 0.9, Alpha: 0.01, Beta: 1},
+	}
+	return NewLeakage(models, []uint{0, 1, 0})
+}
+
+func TestModelCompute(t *testing.T) {
+	model := Model{Nominal: 2, Temperature: 300, Voltage: 1, Alpha: 0.02, Beta: 3}
+
+	assert.Equal(model.Compute(300, 1), 2.0, t)
+	assert.Close(model.Compute(350, 1), 2*math.Exp(1), 1e-12, t)
+	assert.Close(model.Compute(300, 1.1), 2*1.1*math.Exp(0.3), 1e-12, t)
+}
+
+func TestLeakageDistribute(t *testing.T) {
+	leakage := prepareLeakage()
+	assert.Equal(leakage.Distribute(), []float64{1, 0.5, 1}, t)
+
+	leakage.SetTemperature([]float64{368.15, 318.15, 318.15})
+	power := leakage.Distribute()
+	assert.Close(power[0], math.Exp(1), 1e-12, t)
+	assert.Equal(power[1:], []float64{0.5, 1}, t)
+}
+
+func TestLeakageCorrect(t *testing.T) {
+	leakage := prepareLeakage()
+
+	power := []float64{1, 2, 3}
+	leakage.Correct([]float64{368.15, 318.15, 318.15}, power)
+	assert.Close(power[0], 1+math.Exp(1), 1e-12, t)
+	assert.Equal(power[1:], []float64{2.5, 4}, t)
+	assert.Equal(leakage.Distribute()[1:], []float64{0.5, 1}, t)
+}
+
+func TestLeakageProfiles(t *testing.T) {
+	leakage := prepareLeakage()
+	schedule := &time.Schedule{Cores: 3, Span: 1}
+
+	P, ΔT := leakage.Partition(schedule, 0)
+	assert.Equal(P, []float64{1, 0.5, 1}, t)
+	assert.Equal(ΔT, []float64{1}, t)
+
+	P, ns := leakage.Sample(schedule, 0.25, 6)
+	assert.Equal(ns, uint(4), t)
+	assert.Equal(P, []float64{
+		1, 0.5, 1, 1, 0.5, 1, 1, 0.5, 1, 1, 0.5, 1, 0, 0, 0, 0, 0, 0,
+	}, t)
+
+	compute, result := leakage.Progress(schedule), make([]float64, 3)
+	compute(0.5, result)
+	assert.Equal(result, []float64{1, 0.5, 1}, t)
+	compute(2, result)
+	assert.Equal(result, []float64{0, 0, 0}, t)
+}
@@ -0,0 +1,88 @@
+// Package total provides algorithms for calculating the total power, which is
+// the sum of the dynamic and static power.
+package total
+
+import (
+	"github.com/turing-complete/power/dynamic"
+	"github.com/turing-complete/power/static"
+	"github.com/turing-complete/time"
+)
+
+// Power is a power calculator.
+type Power struct {
+	dynamic *dynamic.Power
+	static  *static.Leakage
+}
+
+// New returns a power calculator combining a dynamic and a static one.
+func New(dynamic *dynamic.Power, static *static.Leakage) *Power {
+	return &Power{dynamic: dynamic, static: static}
+}
+
+// Partition computes a power profile with a variable time step dictated by the
+// time moments of power switches. The leakage power is added to each time step.
+func (self *Power) Partition(schedule *time.Schedule, ε float64) ([]float64, []float64) {
+	P, ΔT := self.dynamic.Partition(schedule, ε)
+	leakage, _ := self.static.Partition(schedule, ε)
+
+	nc := schedule.Cores
+	for i := range ΔT {
+		for j := uint(0); j < nc; j++ {
+			P[uint(i)*nc+j] += leakage[j]
+		}
+	}
+
+	return P, ΔT
+}
+
+// Sample computes a power profile with respect to a sampling interval Δt. The
+// second output is the number of samples that are covered by the schedule,
+// which is at most ns.
+func (self *Power) Sample(schedule *time.Schedule, Δt float64, ns uint) ([]float64, uint) {
+	P, ns := self.dynamic.Sample(schedule, Δt, ns)
+	leakage, _ := self.static.Sample(schedule, Δt, ns)
+
+	for i := range leakage {
+		P[i] += leakage[i]
+	}
+
+	return P, ns
+}
+
+// Progress returns a function for computing the power consumption at an
+// arbitrary time moment.
+func (self *Power) Progress(schedule *time.Schedule) func(float64, []float64) {
+	compute := self.dynamic.Progress(schedule)
+	leakage := self.static.Progress(schedule)
+
+	buffer := make([]float64, schedule.Cores)
+	return func(time float64, result []float64) {
+		compute(time, result)
+		leakage(time, buffer)
+		for j := range result {
+			result[j] += buffer[j]
+		}
+	}
+}
+
+// Energy returns the total energy consumption of a schedule.
+func (self *Power) Energy(schedule *time.Schedule) float64 {
+	energy := 0.0
+	for _, e := range self.EnergyPerCore(schedule) {
+		energy += e
+	}
+	return energy
+}
+
+// EnergyPerCore returns the energy consumption of each core. The energy is
+// computed analytically from the start and finish times of the tasks, and the
+// leakage power is drawn over the whole span of the schedule.
+func (self *Power) EnergyPerCore(schedule *time.Schedule) []float64 {
+	energy := self.dynamic.EnergyPerCore(schedule)
+	if schedule.Span > 0 {
+		for j, p := range self.static.Distribute() {
+			energy[j] += p * schedule.Span
+		}
+	}
+	return energy
+}
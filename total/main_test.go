@@ -0,0 +1,74 @@
+package total
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/power/dynamic"
+	"github.com/turing-complete/power/static"
+	"github.com/turing-complete/system"
+	"github.com/turing-complete/time"
+)
+
+func prepare() (*Power, *time.Schedule) {
+	platform := &system.Platform{Cores: []system.Core{{Power: []float64{2}}, {Power: []float64{3}}}}
+	application := &system.Application{Tasks: []system.Task{{Type: 0}, {Type: 0}}}
+
+	schedule := &time.Schedule{
+		Cores:   2,
+		Tasks:   2,
+		Mapping: []uint{0, 1},
+		Start:   []float64{0, 1},
+		Finish:  []float64{1, 2},
+		Span:    2,
+	}
+
+	models := []static.Model{{Nominal: 0.5, Temperature: 300, Voltage: 1}}
+	leakage := static.NewLeakage(models, []uint{0, 0})
+
+	return New(dynamic.New(platform, application), leakage), schedule
+}
+
+func TestPartition(t *testing.T) {
+	power, schedule := prepare()
+
+	P, ΔT := power.Partition(schedule, 1e-14)
+	assert.Equal(P, []float64{2.5, 0.5, 0.5, 3.5}, t)
+	assert.Equal(ΔT, []float64{1, 1}, t)
+}
+
+func TestSample(t *testing.T) {
+	power, schedule := prepare()
+
+	P, ns := power.Sample(schedule, 0.5, 5)
+	assert.Equal(ns, uint(4), t)
+	assert.Equal(P, []float64{2.5, 0.5, 2.5, 0.5, 0.5, 3.5, 0.5, 3.5, 0, 0}, t)
+}
+
+func TestProgress(t *testing.T) {
+	power, schedule := prepare()
+
+	compute, P := power.Progress(schedule), make([]float64, 2)
+
+	compute(0.5, P)
+	assert.Equal(P, []float64{2.5, 0.5}, t)
+	compute(3, P)
+	assert.Equal(P, []float64{0, 0}, t)
+}
+
+func TestEnergy(t *testing.T) {
+	power, schedule := prepare()
+
+	assert.Equal(power.EnergyPerCore(schedule), []float64{3, 4}, t)
+	assert.Equal(power.Energy(schedule), 7.0, t)
+}
+
+func TestProgressCorrected(t *testing.T) {
+	power, schedule := prepare()
+
+	correct := power.dynamic.ProgressCorrected(schedule, power.static)
+
+	P := make([]float64, 2)
+	correct(0.5, []float64{300, 300}, P)
+	assert.Equal(P, []float64{2.5, 0.5}, t)
+}